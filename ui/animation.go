@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │               ANIMATIONS                 │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// Easing maps the elapsed fraction of an animation (0-1) to the fraction of
+// the distance covered. It starts at 0 and ends at 1 but may overshoot in
+// between.
+type Easing func(t float64) float64
+
+func Linear(t float64) float64      { return t }
+func EaseIn(t float64) float64      { return t * t }
+func EaseOut(t float64) float64     { return t * (2 - t) }
+func EaseInCubic(t float64) float64 { return t * t * t }
+func EaseOutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+func EaseInOut(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// Spring overshoots the target and settles on it like a damped spring.
+func Spring(t float64) float64 {
+	if t >= 1 {
+		return 1
+	}
+	return 1 - math.Exp(-6*t)*math.Cos(4*math.Pi*t)
+}
+
+// Bounce hits the target and bounces back on it a few times.
+func Bounce(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	}
+	t -= 2.625 / d
+	return n*t*t + 0.984375
+}
+
+// AnimationFrameMsg advances the animations of the Animator that scheduled
+// it. Pass every message to Animator.Update.
+type AnimationFrameMsg struct {
+	At       time.Time
+	animator *Animator
+	seq      int
+}
+
+// AnimationDoneMsg reports that the animation ID reached its final Value.
+type AnimationDoneMsg struct {
+	ID    string
+	Value float64
+}
+
+// tween is one value moving from one number to another.
+type tween struct {
+	from, to float64
+	duration time.Duration
+	easing   Easing
+	start    time.Time
+	value    float64
+	done     bool
+	loop     bool // Start over instead of finishing.
+}
+
+// Animator runs tweens, values going from one number to another over a
+// duration along an easing curve, on a single frame loop shared by all of
+// them. The loop ticks at FrameRate while an animation is running and stops
+// with the last one, so an idle program does no work. Animators are shared
+// by pointer and safe for concurrent use.
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//		cmd := m.anim.Update(msg)
+//		switch msg := msg.(type) {
+//		case tea.KeyMsg:
+//			return m, m.anim.Animate("panel", 0, 40, 300*time.Millisecond, ui.EaseOutCubic)
+//		case ui.AnimationDoneMsg:
+//			...
+//		}
+//		return m, cmd
+//	}
+//
+//	func (m model) View() string {
+//		x, _ := m.anim.Value("panel")
+//		...
+type Animator struct {
+	mu      sync.Mutex
+	tweens  map[string]*tween
+	colors  map[string]colorAnimation
+	seq     int
+	running bool
+}
+
+// NewAnimator returns an idle animator.
+func NewAnimator() *Animator {
+	return &Animator{tweens: map[string]*tween{}, colors: map[string]colorAnimation{}}
+}
+
+// Animate moves the value id from from to to over d along easing, replacing
+// any animation of id. A nil easing is Linear. The returned command starts
+// the frame loop when it is not running already.
+func (a *Animator) Animate(id string, from, to float64, d time.Duration, easing Easing) tea.Cmd {
+	if easing == nil {
+		easing = Linear
+	}
+	return a.start(id, &tween{from: from, to: to, duration: d, easing: easing, start: time.Now(), value: from})
+}
+
+// Loop moves the value id from from to to over d along easing again and
+// again, until Stop.
+func (a *Animator) Loop(id string, from, to float64, d time.Duration, easing Easing) tea.Cmd {
+	if easing == nil {
+		easing = Linear
+	}
+	return a.start(id, &tween{from: from, to: to, duration: d, easing: easing, start: time.Now(), value: from, loop: d > 0})
+}
+
+func (a *Animator) start(id string, tw *tween) tea.Cmd {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.tweens[id] = tw
+	if a.running {
+		return nil
+	}
+	a.running = true
+	return a.frame()
+}
+
+// AnimateTo moves the value id from wherever it is now to to, which lets an
+// animation change target midway. An unknown id starts from to itself.
+func (a *Animator) AnimateTo(id string, to float64, d time.Duration, easing Easing) tea.Cmd {
+	from, ok := a.Value(id)
+	if !ok {
+		from = to
+	}
+	return a.Animate(id, from, to, d, easing)
+}
+
+// Value returns the current value of id. Finished animations keep their
+// final value until Stop.
+func (a *Animator) Value(id string) (float64, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tw, ok := a.tweens[id]
+	if !ok {
+		return 0, false
+	}
+	return tw.value, true
+}
+
+// Animating reports whether id is still moving.
+func (a *Animator) Animating(id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tw, ok := a.tweens[id]
+	return ok && !tw.done
+}
+
+// Stop forgets id without sending its AnimationDoneMsg.
+func (a *Animator) Stop(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.tweens, id)
+	delete(a.colors, id)
+}
+
+// Update advances the animations on their frames and returns the next frame
+// plus an AnimationDoneMsg for each animation that just finished. Other
+// messages, and frames of other animators, are ignored.
+func (a *Animator) Update(msg tea.Msg) tea.Cmd {
+	frame, ok := msg.(AnimationFrameMsg)
+	if !ok || frame.animator != a {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Parents forwarding the same frame to several children must not start
+	// extra loops.
+	if frame.seq != a.seq {
+		return nil
+	}
+	a.seq++
+
+	var cmds []tea.Cmd
+	active := false
+	for id, tw := range a.tweens {
+		if tw.done {
+			continue
+		}
+		p := 1.0
+		if tw.duration > 0 {
+			elapsed := frame.At.Sub(tw.start)
+			if tw.loop && elapsed >= tw.duration {
+				tw.start = tw.start.Add(elapsed - elapsed%tw.duration)
+				elapsed %= tw.duration
+			}
+			p = math.Min(float64(elapsed)/float64(tw.duration), 1)
+		}
+		tw.value = tw.from + (tw.to-tw.from)*tw.easing(math.Max(p, 0))
+		if p < 1 {
+			active = true
+			continue
+		}
+		tw.value, tw.done = tw.to, true
+		done := AnimationDoneMsg{ID: id, Value: tw.to}
+		cmds = append(cmds, func() tea.Msg { return done })
+	}
+	if active {
+		cmds = append(cmds, a.frame())
+	} else {
+		a.running = false
+	}
+	return tea.Batch(cmds...)
+}
+
+// frame schedules the next frame; a.mu must be held.
+func (a *Animator) frame() tea.Cmd {
+	seq := a.seq
+	return tea.Tick(time.Second/FrameRate, func(t time.Time) tea.Msg {
+		return AnimationFrameMsg{At: t, animator: a, seq: seq}
+	})
+}
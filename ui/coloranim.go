@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │            COLOR ANIMATIONS              │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// ColorCurve gives the color of an animation at progress p (0-1).
+type ColorCurve func(p float64) string
+
+// FadeCurve goes from one "#hex" color to another. Fade text in by going
+// from the background color to its own, and out the other way round.
+func FadeCurve(from, to string) ColorCurve {
+	return func(p float64) string {
+		return InterpolateHexColor(from, to, clamp01(p))
+	}
+}
+
+// PulseCurve goes from a to b and smoothly back to a, which loops
+// seamlessly.
+func PulseCurve(a, b string) ColorCurve {
+	return func(p float64) string {
+		return InterpolateHexColor(a, b, (1-math.Cos(2*math.Pi*clamp01(p)))/2)
+	}
+}
+
+// RampCurve cycles through the "#hex" colors and back to the first one,
+// which loops seamlessly. Use RampColors to follow a MakeRamp gradient.
+func RampCurve(colors ...string) ColorCurve {
+	return func(p float64) string {
+		if len(colors) == 0 {
+			return ""
+		}
+		pos := clamp01(p) * float64(len(colors))
+		i := int(pos) % len(colors)
+		return InterpolateHexColor(colors[i], colors[(i+1)%len(colors)], pos-math.Floor(pos))
+	}
+}
+
+// RampColors returns the foreground colors of a ramp of styles, like the
+// ones MakeRamp and Theme.RampStyles return.
+func RampColors(ramp []lipgloss.Style) []string {
+	colors := make([]string, 0, len(ramp))
+	for _, s := range ramp {
+		if c, ok := s.GetForeground().(lipgloss.Color); ok {
+			colors = append(colors, string(c))
+		}
+	}
+	return colors
+}
+
+func clamp01(p float64) float64 {
+	return math.Max(0, math.Min(p, 1))
+}
+
+// ColorTarget selects the colors of a style a color animation drives.
+type ColorTarget uint8
+
+const (
+	ColorForeground ColorTarget = 1 << iota
+	ColorBackground
+	ColorBorder
+)
+
+// Apply returns s with the colors selected by target set to the color of
+// curve at p.
+func (c ColorCurve) Apply(s lipgloss.Style, target ColorTarget, p float64) lipgloss.Style {
+	col := lipgloss.Color(c(p))
+	if target&ColorForeground != 0 {
+		s = s.Foreground(col)
+	}
+	if target&ColorBackground != 0 {
+		s = s.Background(col)
+	}
+	if target&ColorBorder != 0 {
+		s = s.BorderForeground(col)
+	}
+	return s
+}
+
+type colorAnimation struct {
+	curve  ColorCurve
+	target ColorTarget
+}
+
+// AnimateColor plays curve once over d along easing on the colors of a
+// style selected by target. Style the element with ApplyColor in View.
+//
+//	flash := ui.PulseCurve(t.Color(t.Tokens.Danger), t.Color(t.Tokens.Background))
+//	cmd := anim.AnimateColor("error", flash, ui.ColorForeground, 600*time.Millisecond, nil)
+func (a *Animator) AnimateColor(id string, curve ColorCurve, target ColorTarget, d time.Duration, easing Easing) tea.Cmd {
+	cmd := a.Animate(id, 0, 1, d, easing)
+	a.setColor(id, curve, target)
+	return cmd
+}
+
+// LoopColor plays curve on the colors selected by target every period,
+// until Stop.
+//
+//	rainbow := ui.RampCurve(ui.RampColors(t.Ramp)...)
+//	cmd := anim.LoopColor("nugget", rainbow, ui.ColorBackground, 3*time.Second)
+func (a *Animator) LoopColor(id string, curve ColorCurve, target ColorTarget, period time.Duration) tea.Cmd {
+	cmd := a.Loop(id, 0, 1, period, Linear)
+	a.setColor(id, curve, target)
+	return cmd
+}
+
+func (a *Animator) setColor(id string, curve ColorCurve, target ColorTarget) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.colors[id] = colorAnimation{curve: curve, target: target}
+}
+
+// ApplyColor returns s colored by the color animation id at its current
+// point. Unknown or stopped animations leave s untouched; finished ones keep
+// their last color.
+func (a *Animator) ApplyColor(id string, s lipgloss.Style) lipgloss.Style {
+	a.mu.Lock()
+	ca, ok := a.colors[id]
+	tw := a.tweens[id]
+	a.mu.Unlock()
+	if !ok || tw == nil {
+		return s
+	}
+	p, _ := a.Value(id)
+	return ca.curve.Apply(s, ca.target, p)
+}
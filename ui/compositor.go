@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │               COMPOSITOR                 │ */
+/* ╰──────────────────────────────────────────╯ */
+//
+// lipgloss joins blocks side by side or one under the other; the compositor
+// draws them on top of each other instead, for dialogs, toasts, dropdowns
+// and tooltips:
+//
+//	view := ui.NewCompositor(width, height).
+//		Add(ui.NewLayer(dialog).Center().Shadow(ui.ShadowBlock).DimBelow()).
+//		Render(content)
+
+// Shadow is the drop shadow drawn under a Layer.
+type Shadow int
+
+const (
+	ShadowNone Shadow = iota
+	// ShadowLine outlines the shadow with box-drawing lines, like
+	// GetBoxWithShadowEffectUI.
+	ShadowLine
+	// ShadowBlock fills the shadow with a shade.
+	ShadowBlock
+)
+
+// Layer is a rendered block placed on a Compositor. Like SGR, a Layer is an
+// immutable value: every method returns a modified copy.
+type Layer struct {
+	content  string
+	x, y, z  int
+	centered bool
+	shadow   Shadow
+	dim      bool
+}
+
+// NewLayer returns a layer showing content at the top-left corner.
+func NewLayer(content string) Layer {
+	return Layer{content: content}
+}
+
+// At places the layer with its top-left corner at column x, row y. Parts off
+// the canvas are clipped.
+func (l Layer) At(x, y int) Layer { l.x, l.y, l.centered = x, y, false; return l }
+
+// Center places the layer in the middle of the canvas.
+func (l Layer) Center() Layer { l.centered = true; return l }
+
+// Z sets the stacking order: higher layers are drawn over lower ones, and
+// equal ones in the order they were added.
+func (l Layer) Z(z int) Layer { l.z = z; return l }
+
+// Shadow draws a drop shadow one row down and to the right of the layer.
+func (l Layer) Shadow(s Shadow) Layer { l.shadow = s; return l }
+
+// DimBelow dims everything under the layer, to show it is modal.
+func (l Layer) DimBelow() Layer { l.dim = true; return l }
+
+// Compositor stacks layers over a background of a given size.
+type Compositor struct {
+	width, height int
+	layers        []Layer
+	profile       termenv.Profile
+}
+
+// NewCompositor returns a compositor for a canvas width×height cells. Zero
+// sizes take the size of the background. Dimming and shadows follow the
+// package color profile; see Profile.
+func NewCompositor(width, height int) Compositor {
+	return Compositor{width: width, height: height, profile: ColorProfile()}
+}
+
+// Profile returns the compositor drawing dimming and shadows for p, like a
+// theme's ColorProfile. Ascii draws them without escape sequences.
+func (c Compositor) Profile(p termenv.Profile) Compositor {
+	c.profile = p
+	return c
+}
+
+// Add returns the compositor with layers on top of the ones it has.
+func (c Compositor) Add(layers ...Layer) Compositor {
+	c.layers = append(c.layers[:len(c.layers):len(c.layers)], layers...)
+	return c
+}
+
+// Render draws the layers over background, padded or clipped to the size of
+// the canvas.
+func (c Compositor) Render(background string) string {
+	lines := strings.Split(background, "\n")
+	width, height := c.width, c.height
+	if width <= 0 {
+		for _, line := range lines {
+			width = max(width, StringWidth(line))
+		}
+	}
+	if height <= 0 {
+		height = len(lines)
+	}
+	canvas := make([]string, height)
+	for i := range canvas {
+		if i < len(lines) {
+			canvas[i] = PadRight(SliceColumns(lines[i], 0, width), width)
+		} else {
+			canvas[i] = strings.Repeat(" ", width)
+		}
+	}
+	layers := append([]Layer(nil), c.layers...)
+	sort.SliceStable(layers, func(i, j int) bool { return layers[i].z < layers[j].z })
+	for _, l := range layers {
+		l.draw(canvas, width, c.profile)
+	}
+	return strings.Join(canvas, "\n")
+}
+
+// dimSGR dims what is under modal layers, and their shadows.
+var dimSGR = NewSGR().Dim()
+
+// dim adds the dim attribute to line, keeping its colors. Every SGR sequence
+// of the line can turn it off, so it is set again after each.
+func dim(line string, p termenv.Profile) string {
+	if p == termenv.Ascii {
+		return line
+	}
+	on := dimSGR.String()
+	var sb strings.Builder
+	sb.WriteString(on)
+	for _, seg := range segments(line) {
+		sb.WriteString(seg.text)
+		if seg.kind == segEscape && strings.HasPrefix(seg.text, "\x1b[") && strings.HasSuffix(seg.text, "m") {
+			sb.WriteString(on)
+		}
+	}
+	sb.WriteString(SGRReset)
+	return sb.String()
+}
+
+// draw paints the layer on canvas, a slice of lines width cells wide.
+func (l Layer) draw(canvas []string, width int, p termenv.Profile) {
+	block := strings.Split(l.content, "\n")
+	w := 0
+	for _, line := range block {
+		w = max(w, StringWidth(line))
+	}
+	h := len(block)
+	x, y := l.x, l.y
+	if l.centered {
+		x, y = (width-w)/2, (len(canvas)-h)/2
+	}
+	if l.dim {
+		for i, line := range canvas {
+			canvas[i] = dim(line, p)
+		}
+	}
+	switch l.shadow {
+	case ShadowLine:
+		// The outline of the box moved two columns right and a row down;
+		// only its right and bottom edges show.
+		right := x + w + 1
+		put(canvas, width, right, y+1, "╮")
+		for row := y + 2; row < y+h; row++ {
+			put(canvas, width, right, row, "│")
+		}
+		put(canvas, width, x+2, y+h, "╰"+strings.Repeat("─", max(w-2, 0))+"╯")
+	case ShadowBlock:
+		shadow := dimSGR.Downsample(p)
+		shade := shadow.Render("░")
+		for row := y + 1; row < y+h; row++ {
+			put(canvas, width, x+w, row, shade)
+		}
+		put(canvas, width, x+1, y+h, shadow.Render(strings.Repeat("░", w)))
+	}
+	for i, line := range block {
+		put(canvas, width, x, y+i, PadRight(line, w))
+	}
+}
+
+// put writes s over canvas at column x, row y, clipping what falls outside.
+func put(canvas []string, width, x, y int, s string) {
+	if y < 0 || y >= len(canvas) {
+		return
+	}
+	sw := StringWidth(s)
+	from, to := max(-x, 0), min(sw, width-x)
+	if from >= to {
+		return
+	}
+	line := canvas[y]
+	canvas[y] = SliceColumns(line, 0, x+from) + SliceColumns(s, from, to) + SliceColumns(line, x+to, width)
+}
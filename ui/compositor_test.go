@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestCompositorRender(t *testing.T) {
+	bg := "abcdef\nghijkl\nmnopqr"
+	tests := []struct {
+		name string
+		c    Compositor
+		bg   string
+		want string
+	}{
+		{
+			"no layers pads to size",
+			NewCompositor(4, 4),
+			"ab\ncdefg",
+			"ab  \ncdef\n    \n    ",
+		},
+		{
+			"placed",
+			NewCompositor(0, 0).Add(NewLayer("XY").At(2, 1)),
+			bg,
+			"abcdef\nghXYkl\nmnopqr",
+		},
+		{
+			"clipped left and top",
+			NewCompositor(0, 0).Add(NewLayer("12\n34").At(-1, -1)),
+			bg,
+			"4bcdef\nghijkl\nmnopqr",
+		},
+		{
+			"clipped right and bottom",
+			NewCompositor(0, 0).Add(NewLayer("12\n34").At(5, 2)),
+			bg,
+			"abcdef\nghijkl\nmnopq1",
+		},
+		{
+			"centered",
+			NewCompositor(0, 0).Add(NewLayer("XX").Center()),
+			bg,
+			"abcdef\nghXXkl\nmnopqr",
+		},
+		{
+			"later layers on top",
+			NewCompositor(0, 0).Add(NewLayer("111").At(0, 0), NewLayer("22").At(1, 0)),
+			bg,
+			"122def\nghijkl\nmnopqr",
+		},
+		{
+			"z order beats insertion order",
+			NewCompositor(0, 0).Add(NewLayer("111").At(0, 0).Z(1), NewLayer("22").At(1, 0)),
+			bg,
+			"111def\nghijkl\nmnopqr",
+		},
+		{
+			"wide graphemes cut at the edges",
+			NewCompositor(0, 0).Add(NewLayer("X").At(1, 0)),
+			"日本語",
+			" X本語",
+		},
+		{
+			"block shadow",
+			NewCompositor(0, 0).Profile(termenv.Ascii).Add(NewLayer("XX\nXX").At(1, 0).Shadow(ShadowBlock)),
+			bg,
+			"aXXdef\ngXX░kl\nmn░░qr",
+		},
+		{
+			"line shadow",
+			NewCompositor(6, 4).Profile(termenv.Ascii).Add(NewLayer("┌──┐\n│  │\n└──┘").Shadow(ShadowLine)),
+			"",
+			"┌──┐  \n│  │ ╮\n└──┘ │\n  ╰──╯",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Render(tt.bg); got != tt.want {
+				t.Errorf("Render =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompositorDim(t *testing.T) {
+	red := "\x1b[31mred\x1b[0m bg"
+	plain := NewCompositor(0, 0).Profile(termenv.Ascii).Add(NewLayer("X").At(5, 0).DimBelow()).Render(red)
+	if want := red[:len(red)-2] + "bX"; plain != want {
+		t.Errorf("Ascii dim = %q, want %q", plain, want)
+	}
+	got := NewCompositor(0, 0).Profile(termenv.TrueColor).Add(NewLayer("X").At(5, 0).DimBelow()).Render(red)
+	if !strings.Contains(got, "\x1b[31m") {
+		t.Errorf("dim dropped the colors: %q", got)
+	}
+	if !strings.HasPrefix(got, "\x1b[2m") || !strings.Contains(got, "\x1b[0m\x1b[2m") {
+		t.Errorf("dim not set again after the reset: %q", got)
+	}
+	if StripANSI(got) != "red bX" {
+		t.Errorf("dim changed the text: %q", StripANSI(got))
+	}
+}
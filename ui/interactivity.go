@@ -7,6 +7,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
 )
 
 type (
@@ -20,30 +22,79 @@ func Tick() tea.Cmd {
 	})
 }
 
+// FrameRate is the number of FrameMsg per second Frame schedules.
+const FrameRate = 60
+
 func Frame() tea.Cmd {
-	return tea.Tick(time.Second/60, func(time.Time) tea.Msg {
+	return tea.Tick(time.Second/FrameRate, func(time.Time) tea.Msg {
 		return FrameMsg{}
 	})
 }
 
 func Checkbox(label string, checked bool) string {
+	return defaultTheme.Checkbox(label, checked)
+}
+
+// Checkbox renders a static "[x] label" using the theme's CheckboxStyle.
+func (t *Theme) Checkbox(label string, checked bool) string {
 	if checked {
-		return CheckboxStyle.Render("[x] " + label)
+		return t.CheckboxStyle.Render("[x] " + label)
 	}
 	return fmt.Sprintf("[ ] %s", label)
 }
 
+// Progressbar renders a ProgressBarWidth wide bar in the default theme.
+//
+// Deprecated: use widgets.NewProgressBar(nil).ViewAs(percent), which draws
+// the same bar and can animate it.
 func Progressbar(percent float64) string {
-	w := float64(ProgressBarWidth)
+	return defaultTheme.Progressbar(percent)
+}
 
-	fullSize := int(math.Round(w * percent))
-	var fullCells string
-	for i := 0; i < fullSize; i++ {
-		fullCells += Ramp[i].Render(ProgressFullChar)
+// Progressbar renders a ProgressBarWidth wide bar along the theme's ramp,
+// followed by the percentage.
+//
+// Deprecated: use widgets.NewProgressBar(t).ViewAs(percent), which draws the
+// same bar and can animate it.
+func (t *Theme) Progressbar(percent float64) string {
+	if math.IsNaN(percent) || percent < 0 {
+		percent = 0
 	}
+	percent = math.Min(percent, 1)
+	full := int(math.Round(ProgressBarWidth * percent))
+	ramp := t.RampStyles(ProgressBarWidth)
+	var sb strings.Builder
+	for i := 0; i < ProgressBarWidth; i++ {
+		if i < full {
+			sb.WriteString(ramp[i].Render(ProgressFullChar))
+		} else {
+			sb.WriteString(t.SubtleStyle.Render(ProgressEmptyChar))
+		}
+	}
+	fmt.Fprintf(&sb, " %3.0f%%", percent*100)
+	return sb.String()
+}
 
-	emptySize := int(w) - fullSize
-	emptyCells := strings.Repeat(ProgressEmpty, emptySize)
-
-	return fmt.Sprintf("%s%s %3.0f", fullCells, emptyCells, math.Round(percent*100))
+// RampStyles returns n foreground styles blending evenly through the "#hex"
+// stops, the theme's RampStart and RampEnd when none are given. Progress
+// bars color their cells with it.
+func (t *Theme) RampStyles(n int, stops ...string) []lipgloss.Style {
+	if len(stops) == 0 {
+		stops = []string{t.Palette.RampStart, t.Palette.RampEnd}
+	}
+	colors := make([]colorful.Color, len(stops))
+	for i, s := range stops {
+		colors[i], _ = colorful.Hex(s)
+	}
+	styles := make([]lipgloss.Style, n)
+	for i := range styles {
+		c := colors[0]
+		if len(colors) > 1 && n > 1 {
+			pos := float64(i) / float64(n-1) * float64(len(colors)-1)
+			j := min(int(pos), len(colors)-2)
+			c = colors[j].BlendLuv(colors[j+1], pos-float64(j))
+		}
+		styles[i] = t.renderer.NewStyle().Foreground(lipgloss.Color(c.Clamped().Hex()))
+	}
+	return styles
 }
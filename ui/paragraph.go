@@ -0,0 +1,278 @@
+package ui
+
+import "strings"
+
+/* ╭──────────────────────────────────────────╮ */
+/* │               PARAGRAPHS                 │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// Align is the horizontal alignment of the lines of a Paragraph.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+	AlignJustify // Stretch every line but the last of each paragraph.
+)
+
+// softHyphen marks where a word may be hyphenated. It is invisible unless
+// the word is broken there, in which case it turns into "-".
+const softHyphen = "\u00ad"
+
+// Paragraph lays text out in lines of a fixed width: it word-wraps, aligns
+// or justifies every line and indents the first and following lines of each
+// paragraph independently. Words break at soft hyphens (U+00AD) and after
+// hyphens before being cut, and inline styles (lipgloss, SGR, hyperlinks)
+// carry over line breaks. It is an immutable value: every method returns a
+// modified copy.
+//
+//	help := ui.NewParagraph(60).Align(ui.AlignJustify).Hanging(2)
+//	fmt.Println(theme.DialogBoxStyle.Render(help.Render(text)))
+type Paragraph struct {
+	width   int
+	align   Align
+	indent  int
+	hanging int
+}
+
+// NewParagraph returns a left aligned paragraph width cells wide.
+func NewParagraph(width int) Paragraph {
+	return Paragraph{width: width}
+}
+
+// Width sets the width of the lines, indentation included.
+func (p Paragraph) Width(width int) Paragraph { p.width = width; return p }
+
+// Align sets the alignment of the lines.
+func (p Paragraph) Align(a Align) Paragraph { p.align = a; return p }
+
+// Indent sets the indentation of the first line of each paragraph.
+func (p Paragraph) Indent(n int) Paragraph { p.indent = n; return p }
+
+// Hanging sets the indentation of the lines following the first one of each
+// paragraph, as used by lists and definitions.
+func (p Paragraph) Hanging(n int) Paragraph { p.hanging = n; return p }
+
+// Render lays text out. Line breaks in text start a new paragraph, runs of
+// spaces collapse into one. Every line is padded to the full width so the
+// result is a rectangular block.
+func (p Paragraph) Render(text string) string {
+	var (
+		sb    strings.Builder
+		st    styleState
+		lines []paraLine
+	)
+	for _, words := range paraWords(text) {
+		lines = append(lines, p.layout(words)...)
+	}
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		p.renderLine(&sb, &st, l)
+	}
+	return sb.String()
+}
+
+// paraWord is a run of graphemes with the escapes inside and before it.
+type paraWord struct {
+	segs  []segment
+	width int
+}
+
+// paraLine is one laid out line.
+type paraLine struct {
+	words  []paraWord
+	width  int // Width of the words, without the spaces between them.
+	indent int
+	last   bool // Last line of its paragraph, never justified.
+}
+
+// paraWords splits text into paragraphs of words.
+func paraWords(text string) [][]paraWord {
+	var (
+		paras [][]paraWord
+		words []paraWord
+		cur   paraWord
+	)
+	endWord := func() {
+		if cur.width > 0 {
+			words = append(words, cur)
+			cur = paraWord{}
+		}
+	}
+	endPara := func() {
+		endWord()
+		if len(cur.segs) > 0 {
+			// Escapes after the last word close its styles.
+			if len(words) > 0 {
+				words[len(words)-1].segs = append(words[len(words)-1].segs, cur.segs...)
+			} else {
+				words = append(words, cur)
+			}
+			cur = paraWord{}
+		}
+		paras = append(paras, words)
+		words = nil
+	}
+	for _, seg := range segments(text) {
+		switch {
+		case seg.kind == segControl && seg.text == "\n":
+			endPara()
+		case seg.kind == segControl && seg.text == "\t", seg.kind == segGrapheme && seg.text == " ":
+			endWord()
+		case seg.kind == segControl:
+		case seg.text == softHyphen:
+			seg.width = 0
+			cur.segs = append(cur.segs, seg)
+		default:
+			cur.segs = append(cur.segs, seg)
+			cur.width += seg.width
+		}
+	}
+	endPara()
+	return paras
+}
+
+// hyphenate breaks w at its last soft hyphen or hyphen that leaves a head
+// at most avail cells wide.
+func (w paraWord) hyphenate(avail int) (head, tail paraWord, ok bool) {
+	best, dash, col := -1, false, 0
+	for i, seg := range w.segs {
+		if seg.text == softHyphen {
+			if col > 0 && col+1 <= avail && col < w.width {
+				best, dash = i, true
+			}
+			continue
+		}
+		col += seg.width
+		if seg.text == "-" && col <= avail && col < w.width {
+			best, dash = i, false
+		}
+	}
+	if best < 0 {
+		return w, paraWord{}, false
+	}
+	if dash {
+		head.segs = append(append(head.segs, w.segs[:best]...), segment{text: "-", width: 1})
+		tail.segs = append(tail.segs, w.segs[best+1:]...)
+	} else {
+		head.segs = append(head.segs, w.segs[:best+1]...)
+		tail.segs = append(tail.segs, w.segs[best+1:]...)
+	}
+	head.width, tail.width = segsWidth(head.segs), segsWidth(tail.segs)
+	return head, tail, true
+}
+
+// cut breaks w after avail cells, keeping at least one grapheme in head.
+func (w paraWord) cut(avail int) (head, tail paraWord) {
+	col := 0
+	for i, seg := range w.segs {
+		if seg.kind == segGrapheme && seg.width > 0 && col > 0 && col+seg.width > avail {
+			head.segs = append(head.segs, w.segs[:i]...)
+			tail.segs = append(tail.segs, w.segs[i:]...)
+			head.width, tail.width = col, w.width-col
+			return head, tail
+		}
+		col += seg.width
+	}
+	return w, paraWord{}
+}
+
+func segsWidth(segs []segment) int {
+	w := 0
+	for _, seg := range segs {
+		w += seg.width
+	}
+	return w
+}
+
+// layout fills lines with the words of one paragraph.
+func (p Paragraph) layout(words []paraWord) []paraLine {
+	var lines []paraLine
+	cur := paraLine{indent: p.indent}
+	avail := func() int { return max(p.width-cur.indent, 1) }
+	add := func(w paraWord) {
+		cur.words = append(cur.words, w)
+		cur.width += w.width
+	}
+	next := func() {
+		lines = append(lines, cur)
+		cur = paraLine{indent: p.hanging}
+	}
+	for _, w := range words {
+		for {
+			used := 0 // Cells taken, with the space before w.
+			if len(cur.words) > 0 {
+				used = cur.width + len(cur.words)
+			}
+			if used+w.width <= avail() {
+				add(w)
+				break
+			}
+			if head, tail, ok := w.hyphenate(avail() - used); ok {
+				add(head)
+				w = tail
+			} else if len(cur.words) == 0 {
+				head, tail := w.cut(avail())
+				add(head)
+				w = tail
+				if w.width == 0 {
+					break
+				}
+			}
+			next()
+		}
+	}
+	cur.last = true
+	return append(lines, cur)
+}
+
+// renderLine writes l aligned within the paragraph width.
+func (p Paragraph) renderLine(sb *strings.Builder, st *styleState, l paraLine) {
+	n := len(l.words)
+	extra := max(p.width, l.indent+1) - l.indent - l.width
+	if n > 1 {
+		extra -= n - 1
+	}
+	extra = max(extra, 0)
+	left, spread := l.indent, 0
+	switch p.align {
+	case AlignRight:
+		left += extra
+		extra = 0
+	case AlignCenter:
+		left += extra / 2
+		extra -= extra / 2
+	case AlignJustify:
+		if !l.last && n > 1 {
+			spread, extra = extra, 0
+		}
+	}
+	sb.WriteString(strings.Repeat(" ", left))
+	sb.WriteString(st.open())
+	for i, w := range l.words {
+		if i > 0 {
+			gap := 1
+			if spread > 0 {
+				gap += spread / (n - 1)
+				if i <= spread%(n-1) {
+					gap++
+				}
+			}
+			sb.WriteString(strings.Repeat(" ", gap))
+		}
+		for _, seg := range w.segs {
+			switch {
+			case seg.kind == segEscape:
+				st.update(seg.text)
+				sb.WriteString(seg.text)
+			case seg.text != softHyphen:
+				sb.WriteString(seg.text)
+			}
+		}
+	}
+	sb.WriteString(st.close())
+	sb.WriteString(strings.Repeat(" ", extra))
+}
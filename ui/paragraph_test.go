@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParagraphRender(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Paragraph
+		text string
+		want []string
+	}{
+		{"left", NewParagraph(10), "the quick brown fox", []string{"the quick ", "brown fox "}},
+		{"right", NewParagraph(10).Align(AlignRight), "the quick brown fox", []string{" the quick", " brown fox"}},
+		{"center", NewParagraph(11).Align(AlignCenter), "ab cd", []string{"   ab cd   "}},
+		{"justify", NewParagraph(10).Align(AlignJustify), "aa bb cc dd ee", []string{"aa  bb  cc", "dd ee     "}},
+		{"justify uneven", NewParagraph(9).Align(AlignJustify), "aa bb cc dd", []string{"aa  bb cc", "dd       "}},
+		{"indent and hanging", NewParagraph(10).Indent(2).Hanging(4), "one two three four", []string{"  one two ", "    three ", "    four  "}},
+		{"soft hyphen", NewParagraph(10), "xx hyphen\u00adated", []string{"xx hyphen-", "ated      "}},
+		{"unused soft hyphen", NewParagraph(12), "hyphen\u00adated", []string{"hyphenated  "}},
+		{"hyphen", NewParagraph(8), "well-known", []string{"well-   ", "known   "}},
+		{"long word", NewParagraph(4), "abcdefghij", []string{"abcd", "efgh", "ij  "}},
+		{"paragraphs", NewParagraph(6), "a   b\nc", []string{"a b   ", "c     "}},
+		{"wide graphemes", NewParagraph(4), "日本語", []string{"日本", "語  "}},
+		{"styles carry over", NewParagraph(4), "\x1b[1mab cd\x1b[0m", []string{"\x1b[1mab\x1b[0m  ", "\x1b[1mcd\x1b[0m  "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Split(tt.p.Render(tt.text), "\n")
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Render(%q) =\n%q\nwant\n%q", tt.text, got, tt.want)
+			}
+			for _, line := range got {
+				if w := StringWidth(line); w != tt.p.width {
+					t.Errorf("line %q is %d cells wide, want %d", line, w, tt.p.width)
+				}
+			}
+		})
+	}
+}
+
+func TestParagraphImmutable(t *testing.T) {
+	p := NewParagraph(10)
+	p.Align(AlignRight).Indent(2).Width(4)
+	if got := p.Render("ab"); got != "ab        " {
+		t.Errorf("the setters changed the receiver: Render = %q", got)
+	}
+}
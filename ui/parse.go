@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │             TYPED PARSERS                │ */
+/* ╰──────────────────────────────────────────╯ */
+//
+// Unlike Float64FromString, which returns 0 for anything it cannot read,
+// these parsers report what is wrong with the input, in a form fit to show
+// under a text field.
+
+// ParseFloat reads a number, accepting a comma as decimal separator.
+func ParseFloat(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return f, nil
+}
+
+// ParseDecimal reads a number with at most places decimals, the inputs
+// FormatStringAsFloatWithDecimals formats.
+func ParseDecimal(s string, places int) (float64, error) {
+	f, err := ParseFloat(s)
+	if err != nil {
+		return 0, err
+	}
+	s = strings.Replace(strings.TrimSpace(s), ",", ".", 1)
+	if i := strings.IndexByte(s, '.'); i >= 0 && len(s)-i-1 > places {
+		return 0, fmt.Errorf("%q has more than %d decimals", s, places)
+	}
+	return f, nil
+}
+
+// ParseCurrency reads an amount of money like "€ 1.234,56", "$1,234.56" or
+// "12.5". Currency symbols and spaces are ignored; when both "." and ","
+// appear, the last one is the decimal separator and the other groups
+// thousands. A lone separator groups thousands when it repeats or is
+// followed by exactly three digits, as in "$1,234", and is the decimal
+// separator otherwise.
+func ParseCurrency(s string, places int) (float64, error) {
+	amount := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) || r == '.' || r == ',' || r == '-' {
+			return r
+		}
+		return -1
+	}, s)
+	if strings.Contains(amount, ".") && strings.Contains(amount, ",") {
+		dec := ","
+		if strings.LastIndex(amount, ".") > strings.LastIndex(amount, ",") {
+			dec = "."
+		}
+		thousands := map[string]string{".": ",", ",": "."}[dec]
+		amount = strings.ReplaceAll(amount, thousands, "")
+	} else if i := strings.IndexAny(amount, ".,"); i >= 0 {
+		sep := amount[i : i+1]
+		if strings.Count(amount, sep) > 1 || len(amount)-i-1 == 3 {
+			amount = strings.ReplaceAll(amount, sep, "")
+		}
+	}
+	f, err := ParseDecimal(amount, places)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an amount with up to %d decimals", s, places)
+	}
+	return f, nil
+}
+
+// ParseDate reads a date in the given time.Parse layout.
+func ParseDate(s, layout string) (time.Time, error) {
+	t, err := time.Parse(layout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a valid date (%s)", s, layout)
+	}
+	return t, nil
+}
+
+// ParseClock reads a time of day as "15:04" or "15:04:05" and returns it as
+// the duration since midnight.
+func ParseClock(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a valid time (HH:MM)", s)
+}
+
+// ParseIPv4 reads a dotted IPv4 address.
+func ParseIPv4(s string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(s)).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an IPv4 address", s)
+	}
+	return ip, nil
+}
+
+// ParsePhone reads a phone number, keeping its digits and a leading "+".
+// It must have between 6 and 15 digits, the E.164 maximum.
+func ParsePhone(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	var sb strings.Builder
+	for i, r := range s {
+		switch {
+		case unicode.IsDigit(r):
+			sb.WriteRune(r)
+		case r == '+' && i == 0:
+			sb.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", fmt.Errorf("%q is not a phone number", s)
+		}
+	}
+	phone := sb.String()
+	if n := len(strings.TrimPrefix(phone, "+")); n < 6 || n > 15 {
+		return "", fmt.Errorf("%q is not a phone number", s)
+	}
+	return phone, nil
+}
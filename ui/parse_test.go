@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		s    string
+		want float64
+		ok   bool
+	}{
+		{"2.5", 2.5, true},
+		{" 3,5 ", 3.5, true},
+		{"-0,25", -0.25, true},
+		{"1e3", 1000, true},
+		{"1.234,5", 0, false},
+		{"", 0, false},
+		{"abc", 0, false},
+		{"NaN", 0, false},
+		{"Inf", 0, false},
+		{"-infinity", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseFloat(tt.s)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseFloat(%q) = %v, %v", tt.s, got, err)
+		}
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		s      string
+		places int
+		want   float64
+		ok     bool
+	}{
+		{"1.23", 2, 1.23, true},
+		{"1,23", 2, 1.23, true},
+		{"1.2", 2, 1.2, true},
+		{"5", 0, 5, true},
+		{"1.234", 2, 0, false},
+		{"5.0", 0, 0, false},
+		{"x", 2, 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseDecimal(tt.s, tt.places)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseDecimal(%q, %d) = %v, %v", tt.s, tt.places, got, err)
+		}
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		s    string
+		want float64
+		ok   bool
+	}{
+		{"€ 1.234,56", 1234.56, true},
+		{"$1,234.56", 1234.56, true},
+		{"12.5", 12.5, true},
+		{"-3,00 €", -3, true},
+		{"1.234.567,89 €", 1234567.89, true},
+		{"$1,234", 1234, true},
+		{"1.234 €", 1234, true},
+		{"1,234,567", 1234567, true},
+		{"1,5", 1.5, true},
+		{"12.34", 12.34, true},
+		{"12.3456", 0, false},
+		{"€", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseCurrency(tt.s, 2)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseCurrency(%q) = %v, %v", tt.s, got, err)
+		}
+	}
+}
+
+func TestParseCurrencyThousandsWithMorePlaces(t *testing.T) {
+	if got, err := ParseCurrency("$1,234", 3); err != nil || got != 1234 {
+		t.Errorf("ParseCurrency(\"$1,234\", 3) = %v, %v, want 1234", got, err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	got, err := ParseDate(" 2024-02-29 ", "2006-01-02")
+	if err != nil || !got.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseDate = %v, %v", got, err)
+	}
+	for _, s := range []string{"2023-02-29", "2024-13-01", "29/02/2024", ""} {
+		if _, err := ParseDate(s, "2006-01-02"); err == nil {
+			t.Errorf("ParseDate(%q) succeeded", s)
+		}
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Duration
+		ok   bool
+	}{
+		{"09:30", 9*time.Hour + 30*time.Minute, true},
+		{" 00:00 ", 0, true},
+		{"23:59:59", 23*time.Hour + 59*time.Minute + 59*time.Second, true},
+		{"24:00", 0, false},
+		{"12:60", 0, false},
+		{"noon", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseClock(tt.s)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseClock(%q) = %v, %v", tt.s, got, err)
+		}
+	}
+}
+
+func TestParseIPv4(t *testing.T) {
+	tests := []struct {
+		s  string
+		ok bool
+	}{
+		{"192.168.0.1", true},
+		{" 10.0.0.255 ", true},
+		{"256.1.1.1", false},
+		{"1.2.3", false},
+		{"::1", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		ip, err := ParseIPv4(tt.s)
+		if (err == nil) != tt.ok || tt.ok && len(ip) != 4 {
+			t.Errorf("ParseIPv4(%q) = %v, %v", tt.s, ip, err)
+		}
+	}
+}
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+		ok   bool
+	}{
+		{"+34 600 (123) 456", "+34600123456", true},
+		{"600-123.456", "600123456", true},
+		{"123456", "123456", true},
+		{"12345", "", false},
+		{"1234567890123456", "", false},
+		{"600 12a 456", "", false},
+		{"6+00123456", "", false},
+	}
+	for _, tt := range tests {
+		got, err := ParsePhone(tt.s)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParsePhone(%q) = %q, %v", tt.s, got, err)
+		}
+	}
+}
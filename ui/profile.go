@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/muesli/termenv"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │              COLOR PROFILES              │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// ansiCodes are the raw escapes behind the ANSI color variables in styles.go.
+// They are blanked out when the color profile is Ascii.
+var ansiCodes = map[*string]string{}
+
+func init() {
+	for _, v := range []*string{
+		&Red, &Green, &Yellow, &Blue, &Magenta, &Cyan, &White, &Black, &Gray,
+		&DarkYellow, &DarkBlue, &DarkRed, &DarkGreen, &DarkCyan, &DarkMagenta, &DarkWhite,
+		&Reset, &Bold, &BoldRed, &BoldBlue, &BoldCyan, &BoldOrange, &BoldGreen, &BoldYellow, &BoldMagenta,
+		&Underline, &UnderlineRed, &UnderlineBlue, &UnderlineOrange, &UnderlineGreen, &UnderlineYellow,
+		&BoldDarkYellow,
+	} {
+		ansiCodes[v] = *v
+	}
+	SetColorProfile(DetectColorProfile())
+}
+
+// DetectColorProfile works out the color profile of stdout. NO_COLOR (any
+// value) disables colors. FORCE_COLOR overrides terminal detection, which is
+// handy in CI logs: "0" or "false" disables colors, "1", "true" or empty
+// forces 16 colors, "2" forces 256 colors and "3" forces true color.
+func DetectColorProfile() termenv.Profile {
+	if p, ok := forcedColorProfile(); ok {
+		return p
+	}
+	return termenv.NewOutput(os.Stdout).EnvColorProfile()
+}
+
+// forcedColorProfile returns the profile NO_COLOR or FORCE_COLOR ask for.
+func forcedColorProfile() (termenv.Profile, bool) {
+	if os.Getenv("NO_COLOR") != "" {
+		return termenv.Ascii, true
+	}
+	force, ok := os.LookupEnv("FORCE_COLOR")
+	if !ok {
+		return 0, false
+	}
+	switch strings.ToLower(force) {
+	case "0", "false":
+		return termenv.Ascii, true
+	case "2":
+		return termenv.ANSI256, true
+	case "3":
+		return termenv.TrueColor, true
+	}
+	return termenv.ANSI, true
+}
+
+// ColorProfile returns the profile every package-level helper renders with.
+func ColorProfile() termenv.Profile {
+	return Term
+}
+
+// SetColorProfile changes the profile used by the package-level helpers:
+// the ANSI color variables, ColorFg, GetStatusColor, DefaultRenderer and
+// therefore the style globals, and the pre-rendered strings like Divider and
+// Dot. Themes bound to another renderer (see
+// Theme.WithRenderer) are not affected.
+func SetColorProfile(p termenv.Profile) {
+	Term = p
+	DefaultRenderer().SetColorProfile(p)
+	for v, code := range ansiCodes {
+		if p == termenv.Ascii {
+			*v = ""
+		} else {
+			*v = code
+		}
+	}
+	renderGlobals()
+}
+
+// renderGlobals renders again the package-level strings drawn with the
+// default theme, so they follow the profile.
+func renderGlobals() {
+	Divider = defaultTheme.Divider()
+	CheckMark = defaultTheme.CheckMark()
+	ProgressEmpty = defaultTheme.SubtleStyle.Render(ProgressEmptyChar)
+	DotStyle = defaultTheme.DotStyle.Render(DotChar)
+	Dot = defaultTheme.DotStyle.Render(DotChar)
+}
+
+// Downsample converts a "#hex" or ANSI color to the closest color p can
+// show. It returns "" for Ascii, which lipgloss treats as no color.
+func Downsample(color string, p termenv.Profile) string {
+	switch c := p.Color(color).(type) {
+	case termenv.RGBColor:
+		return string(c)
+	case termenv.ANSI256Color:
+		return strconv.Itoa(int(c))
+	case termenv.ANSIColor:
+		return strconv.Itoa(int(c))
+	}
+	return ""
+}
+
+var (
+	auroraFg = []aurora.Color{aurora.BlackFg, aurora.RedFg, aurora.GreenFg, aurora.YellowFg, aurora.BlueFg, aurora.MagentaFg, aurora.CyanFg, aurora.WhiteFg}
+	auroraBg = []aurora.Color{aurora.BlackBg, aurora.RedBg, aurora.GreenBg, aurora.YellowBg, aurora.BlueBg, aurora.MagentaBg, aurora.CyanBg, aurora.WhiteBg}
+)
+
+// auroraColors colors s with fg on bg, downsampled to p. Aurora cannot emit
+// true color, so TrueColor falls back to the 256 color palette.
+func auroraColors(s string, fg, bg string, p termenv.Profile) aurora.Value {
+	switch p {
+	case termenv.Ascii:
+		return aurora.NewAurora(false).Reset(s)
+	case termenv.ANSI:
+		f, b := ansi16(fg), ansi16(bg)
+		c := auroraFg[f%8] | auroraBg[b%8]
+		if f >= 8 {
+			c |= aurora.BrightFg
+		}
+		if b >= 8 {
+			c |= aurora.BrightBg
+		}
+		return aurora.Colorize(s, c)
+	}
+	return aurora.BgIndex(ansi256(bg), aurora.Index(ansi256(fg), s))
+}
+
+// ansi16 converts a "#hex" or ANSI color to its closest 16-color index.
+func ansi16(color string) uint8 {
+	if c, ok := termenv.ANSI.Color(color).(termenv.ANSIColor); ok {
+		return uint8(c)
+	}
+	return 0
+}
+
+// ansi256 converts a "#hex" or ANSI color to its closest 256-color index.
+func ansi256(color string) uint8 {
+	switch c := termenv.ANSI256.Color(color).(type) {
+	case termenv.ANSI256Color:
+		return uint8(c)
+	case termenv.ANSIColor:
+		return uint8(c)
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestSetColorProfileRendersGlobals(t *testing.T) {
+	defer SetColorProfile(ColorProfile())
+	globals := func() map[string]string {
+		return map[string]string{
+			"Divider":       Divider,
+			"CheckMark":     CheckMark,
+			"ProgressEmpty": ProgressEmpty,
+			"DotStyle":      DotStyle,
+			"Dot":           Dot,
+		}
+	}
+	SetColorProfile(termenv.TrueColor)
+	for name, s := range globals() {
+		if !strings.Contains(s, "\x1b[") {
+			t.Errorf("%s = %q under TrueColor, want colors", name, s)
+		}
+	}
+	SetColorProfile(termenv.Ascii)
+	for name, s := range globals() {
+		if strings.Contains(s, "\x1b[") {
+			t.Errorf("%s = %q under Ascii, want plain text", name, s)
+		}
+	}
+}
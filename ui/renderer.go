@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Renderer is an output plus everything needed to color it right: its color
+// profile and whether its terminal has a dark background. Derive themes from
+// it (Renderer.DefaultTheme, Theme.WithRenderer) to render for somewhere else
+// than the local stdout, like an SSH session whose client terminal differs
+// from the server's, a buffer or a log file.
+type Renderer struct {
+	w  io.Writer
+	lg *lipgloss.Renderer
+}
+
+var defaultRenderer = &Renderer{w: os.Stdout, lg: lipgloss.DefaultRenderer()}
+
+// DefaultRenderer returns the renderer for stdout that the package-level
+// helpers and DefaultTheme use.
+func DefaultRenderer() *Renderer {
+	return defaultRenderer
+}
+
+// NewRenderer creates a renderer for w. The color profile and background are
+// detected from w and its environment the first time they are needed; pass
+// termenv options (WithEnvironment, WithTTY, WithProfile...) to describe a
+// remote terminal. Writers that are not terminals, like buffers, detect as
+// Ascii; call SetColorProfile to keep colors. For stdout and stderr NO_COLOR
+// and FORCE_COLOR apply too.
+func NewRenderer(w io.Writer, opts ...termenv.OutputOption) *Renderer {
+	r := &Renderer{w: w, lg: lipgloss.NewRenderer(w, opts...)}
+	if w == os.Stdout || w == os.Stderr {
+		if p, ok := forcedColorProfile(); ok {
+			r.SetColorProfile(p)
+		}
+	}
+	return r
+}
+
+// Write sends p to the renderer's output, so it can be used with fmt.Fprint.
+func (r *Renderer) Write(p []byte) (int, error) {
+	return r.w.Write(p)
+}
+
+// Writer returns the renderer's output.
+func (r *Renderer) Writer() io.Writer {
+	return r.w
+}
+
+// Lipgloss returns the underlying lipgloss renderer.
+func (r *Renderer) Lipgloss() *lipgloss.Renderer {
+	return r.lg
+}
+
+// ColorProfile returns the color profile of the output.
+func (r *Renderer) ColorProfile() termenv.Profile {
+	return r.lg.ColorProfile()
+}
+
+// SetColorProfile overrides the detected color profile.
+func (r *Renderer) SetColorProfile(p termenv.Profile) {
+	r.lg.SetColorProfile(p)
+}
+
+// HasDarkBackground reports whether the output's terminal has a dark
+// background, which picks the Dark side of every AdaptiveColor.
+func (r *Renderer) HasDarkBackground() bool {
+	return r.lg.HasDarkBackground()
+}
+
+// SetHasDarkBackground overrides the detected background.
+func (r *Renderer) SetHasDarkBackground(dark bool) {
+	r.lg.SetHasDarkBackground(dark)
+}
+
+// NewStyle creates a lipgloss style that renders for this output.
+func (r *Renderer) NewStyle() lipgloss.Style {
+	return r.lg.NewStyle()
+}
+
+// ColorFg colors val like the package-level ColorFg, for this output.
+func (r *Renderer) ColorFg(val, color string) string {
+	return termenv.String(val).Foreground(r.ColorProfile().Color(color)).String()
+}
+
+// DefaultTheme returns the default theme bound to this renderer.
+func (r *Renderer) DefaultTheme() *Theme {
+	return DefaultTheme().WithRenderer(r)
+}
+
+// ThemeByName returns a registered theme bound to this renderer.
+func (r *Renderer) ThemeByName(name string) (*Theme, error) {
+	t, err := ThemeByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return t.WithRenderer(r), nil
+}
@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │               SGR BUILDER                │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// SGRColor is a terminal color: one of the 16 ANSI colors, an entry of the
+// 256 color palette or a 24-bit RGB value. The zero value means "unset".
+type SGRColor struct {
+	kind    sgrColorKind
+	index   uint8
+	r, g, b uint8
+}
+
+type sgrColorKind uint8
+
+const (
+	sgrNoColor sgrColorKind = iota
+	sgrANSI16
+	sgrANSI256
+	sgrRGB
+)
+
+// The 16 ANSI colors. Their exact look depends on the terminal palette.
+var (
+	SGRBlack         = ANSIColor(0)
+	SGRRed           = ANSIColor(1)
+	SGRGreen         = ANSIColor(2)
+	SGRYellow        = ANSIColor(3)
+	SGRBlue          = ANSIColor(4)
+	SGRMagenta       = ANSIColor(5)
+	SGRCyan          = ANSIColor(6)
+	SGRWhite         = ANSIColor(7)
+	SGRBrightBlack   = ANSIColor(8)
+	SGRBrightRed     = ANSIColor(9)
+	SGRBrightGreen   = ANSIColor(10)
+	SGRBrightYellow  = ANSIColor(11)
+	SGRBrightBlue    = ANSIColor(12)
+	SGRBrightMagenta = ANSIColor(13)
+	SGRBrightCyan    = ANSIColor(14)
+	SGRBrightWhite   = ANSIColor(15)
+)
+
+// ANSIColor returns one of the 16 ANSI colors (0-15).
+func ANSIColor(i uint8) SGRColor {
+	return SGRColor{kind: sgrANSI16, index: i & 15}
+}
+
+// Color256 returns an entry of the 256 color palette.
+func Color256(i uint8) SGRColor {
+	return SGRColor{kind: sgrANSI256, index: i}
+}
+
+// RGB returns a 24-bit color.
+func RGB(r, g, b uint8) SGRColor {
+	return SGRColor{kind: sgrRGB, r: r, g: g, b: b}
+}
+
+// ParseSGRColor reads a "#RRGGBB"/"#RGB" hex color or an ANSI code "0"-"255",
+// the same notations lipgloss.Color accepts.
+func ParseSGRColor(s string) (SGRColor, error) {
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return SGRColor{}, fmt.Errorf("invalid hex color %q", s)
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return SGRColor{}, fmt.Errorf("invalid hex color %q", s)
+		}
+		return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 || i > 255 {
+		return SGRColor{}, fmt.Errorf("invalid color %q (want \"#RRGGBB\" or 0-255)", s)
+	}
+	if i < 16 {
+		return ANSIColor(uint8(i)), nil
+	}
+	return Color256(uint8(i)), nil
+}
+
+// IsSet reports whether c holds a color.
+func (c SGRColor) IsSet() bool {
+	return c.kind != sgrNoColor
+}
+
+// params returns the SGR parameters selecting c; base is 30 for foreground,
+// 40 for background and 58 (extended only) for underline color.
+func (c SGRColor) params(base int) []string {
+	extended := strconv.Itoa(base + 8)
+	if base == 58 {
+		extended = "58"
+	}
+	switch c.kind {
+	case sgrANSI16:
+		if base == 58 {
+			return []string{extended, "5", strconv.Itoa(int(c.index))}
+		}
+		if c.index >= 8 {
+			return []string{strconv.Itoa(base + 60 + int(c.index-8))}
+		}
+		return []string{strconv.Itoa(base + int(c.index))}
+	case sgrANSI256:
+		return []string{extended, "5", strconv.Itoa(int(c.index))}
+	case sgrRGB:
+		return []string{extended, "2", strconv.Itoa(int(c.r)), strconv.Itoa(int(c.g)), strconv.Itoa(int(c.b))}
+	}
+	return nil
+}
+
+// hex returns c as "#rrggbb" for termenv conversions.
+func (c SGRColor) hex() string {
+	switch c.kind {
+	case sgrRGB:
+		return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+	case sgrANSI16, sgrANSI256:
+		return termenv.ANSI256Color(c.index).String()
+	}
+	return ""
+}
+
+// Downsample converts c to the closest color p can show; Ascii drops it.
+func (c SGRColor) Downsample(p termenv.Profile) SGRColor {
+	if !c.IsSet() {
+		return c
+	}
+	switch p {
+	case termenv.Ascii:
+		return SGRColor{}
+	case termenv.ANSI:
+		if c.kind == sgrANSI16 {
+			return c
+		}
+		return ANSIColor(ansi16(c.hex()))
+	case termenv.ANSI256:
+		if c.kind != sgrRGB {
+			return c
+		}
+		i := ansi256(c.hex())
+		if i < 16 {
+			return ANSIColor(i)
+		}
+		return Color256(i)
+	}
+	return c
+}
+
+// UnderlineStyle is the shape of an underline. Styles other than single
+// need a terminal supporting the "4:n" extension (kitty, wezterm, vte...).
+type UnderlineStyle uint8
+
+const (
+	NoUnderline UnderlineStyle = iota
+	SingleUnderline
+	DoubleUnderline
+	CurlyUnderline
+	DottedUnderline
+	DashedUnderline
+)
+
+type sgrAttr uint16
+
+const (
+	sgrBold sgrAttr = 1 << iota
+	sgrDim
+	sgrItalic
+	sgrBlink
+	sgrRapidBlink
+	sgrReverse
+	sgrConceal
+	sgrStrikethrough
+	sgrOverline
+)
+
+// The attribute parameters, in the order they are written.
+var sgrAttrParams = []struct {
+	attr  sgrAttr
+	param string
+}{
+	{sgrBold, "1"},
+	{sgrDim, "2"},
+	{sgrItalic, "3"},
+	{sgrBlink, "5"},
+	{sgrRapidBlink, "6"},
+	{sgrReverse, "7"},
+	{sgrConceal, "8"},
+	{sgrStrikethrough, "9"},
+	{sgrOverline, "53"},
+}
+
+// SGRReset turns every attribute off.
+const SGRReset = "\033[0m"
+
+// SGR composes Select Graphic Rendition attributes into a single escape
+// sequence. It is an immutable value: every method returns a modified copy.
+//
+//	warn := ui.NewSGR().Bold().Fg(ui.SGRYellow)
+//	fmt.Println(warn.Render("careful"))
+type SGR struct {
+	attrs     sgrAttr
+	underline UnderlineStyle
+	fg, bg    SGRColor
+	ulColor   SGRColor
+	link      string
+}
+
+// NewSGR returns an SGR with no attributes.
+func NewSGR() SGR {
+	return SGR{}
+}
+
+func (s SGR) Bold() SGR          { s.attrs |= sgrBold; return s }
+func (s SGR) Dim() SGR           { s.attrs |= sgrDim; return s }
+func (s SGR) Italic() SGR        { s.attrs |= sgrItalic; return s }
+func (s SGR) Blink() SGR         { s.attrs |= sgrBlink; return s }
+func (s SGR) RapidBlink() SGR    { s.attrs |= sgrRapidBlink; return s }
+func (s SGR) Reverse() SGR       { s.attrs |= sgrReverse; return s }
+func (s SGR) Conceal() SGR       { s.attrs |= sgrConceal; return s }
+func (s SGR) Strikethrough() SGR { s.attrs |= sgrStrikethrough; return s }
+func (s SGR) Overline() SGR      { s.attrs |= sgrOverline; return s }
+
+// Underline sets a single underline.
+func (s SGR) Underline() SGR { return s.UnderlineStyle(SingleUnderline) }
+
+// UnderlineStyle sets the shape of the underline; NoUnderline removes it.
+func (s SGR) UnderlineStyle(u UnderlineStyle) SGR { s.underline = u; return s }
+
+// UnderlineColor colors the underline independently from the text.
+func (s SGR) UnderlineColor(c SGRColor) SGR { s.ulColor = c; return s }
+
+// Fg sets the foreground color.
+func (s SGR) Fg(c SGRColor) SGR { s.fg = c; return s }
+
+// Bg sets the background color.
+func (s SGR) Bg(c SGRColor) SGR { s.bg = c; return s }
+
+// Hyperlink makes Render wrap the text in an OSC 8 hyperlink to url.
+func (s SGR) Hyperlink(url string) SGR { s.link = url; return s }
+
+// Downsample converts every color to the closest one p can show. Ascii drops
+// colors and attributes alike, like termenv does.
+func (s SGR) Downsample(p termenv.Profile) SGR {
+	if p == termenv.Ascii {
+		return SGR{link: s.link}
+	}
+	s.fg = s.fg.Downsample(p)
+	s.bg = s.bg.Downsample(p)
+	s.ulColor = s.ulColor.Downsample(p)
+	return s
+}
+
+// IsZero reports whether s has no attribute at all.
+func (s SGR) IsZero() bool {
+	return s.attrs == 0 && s.underline == NoUnderline && !s.fg.IsSet() && !s.bg.IsSet() && !s.ulColor.IsSet()
+}
+
+// String returns the escape sequence turning the attributes on, with every
+// parameter in a single CSI. It is empty when s has no attribute.
+func (s SGR) String() string {
+	if s.IsZero() {
+		return ""
+	}
+	params := make([]string, 0, 8)
+	for _, a := range sgrAttrParams {
+		if s.attrs&a.attr != 0 {
+			params = append(params, a.param)
+		}
+	}
+	switch s.underline {
+	case NoUnderline:
+	case SingleUnderline:
+		params = append(params, "4")
+	default:
+		params = append(params, "4:"+strconv.Itoa(int(s.underline)))
+	}
+	params = append(params, s.fg.params(30)...)
+	params = append(params, s.bg.params(40)...)
+	params = append(params, s.ulColor.params(58)...)
+	return "\033[" + strings.Join(params, ";") + "m"
+}
+
+// Render wraps text in the attributes, resetting them afterwards.
+func (s SGR) Render(text string) string {
+	if seq := s.String(); seq != "" {
+		text = seq + text + SGRReset
+	}
+	if s.link != "" {
+		text = "\033]8;;" + s.link + "\033\\" + text + "\033]8;;\033\\"
+	}
+	return text
+}
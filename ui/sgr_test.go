@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestSGRString(t *testing.T) {
+	tests := []struct {
+		name string
+		sgr  SGR
+		want string
+	}{
+		{"zero", NewSGR(), ""},
+		{"attributes in order", NewSGR().Overline().Italic().Bold(), "\x1b[1;3;53m"},
+		{"ansi colors", NewSGR().Fg(SGRRed).Bg(SGRBrightBlue), "\x1b[31;104m"},
+		{"256 colors", NewSGR().Fg(Color256(208)).Bg(Color256(17)), "\x1b[38;5;208;48;5;17m"},
+		{"rgb", NewSGR().Fg(RGB(1, 2, 3)), "\x1b[38;2;1;2;3m"},
+		{"single underline", NewSGR().Underline(), "\x1b[4m"},
+		{"curly underline", NewSGR().UnderlineStyle(CurlyUnderline).UnderlineColor(SGRRed), "\x1b[4:3;58;5;1m"},
+		{"underline rgb", NewSGR().UnderlineColor(RGB(9, 8, 7)), "\x1b[58;2;9;8;7m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sgr.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSGRRender(t *testing.T) {
+	if got, want := NewSGR().Bold().Render("hi"), "\x1b[1mhi"+SGRReset; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+	if got := NewSGR().Render("hi"); got != "hi" {
+		t.Errorf("Render without attributes = %q, want the text alone", got)
+	}
+	got := NewSGR().Bold().Hyperlink("http://x").Render("hi")
+	if want := "\x1b]8;;http://x\x1b\\\x1b[1mhi" + SGRReset + "\x1b]8;;\x1b\\"; got != want {
+		t.Errorf("Render with a link = %q, want %q", got, want)
+	}
+	if w := StringWidth(got); w != 2 {
+		t.Errorf("StringWidth of a rendered link = %d, want 2", w)
+	}
+}
+
+func TestSGRDownsample(t *testing.T) {
+	red := RGB(255, 0, 0)
+	tests := []struct {
+		name string
+		c    SGRColor
+		p    termenv.Profile
+		want SGRColor
+	}{
+		{"true color keeps rgb", red, termenv.TrueColor, red},
+		{"256 colors", red, termenv.ANSI256, Color256(196)},
+		{"256 keeps palette", Color256(208), termenv.ANSI256, Color256(208)},
+		{"ansi keeps ansi", SGRBrightBlue, termenv.ANSI, SGRBrightBlue},
+		{"ansi from rgb", red, termenv.ANSI, SGRBrightRed},
+		{"ascii drops", red, termenv.Ascii, SGRColor{}},
+		{"unset stays unset", SGRColor{}, termenv.ANSI, SGRColor{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Downsample(tt.p); got != tt.want {
+				t.Errorf("Downsample(%v) = %+v, want %+v", tt.p, got, tt.want)
+			}
+		})
+	}
+
+	s := NewSGR().Bold().Fg(red).Hyperlink("http://x")
+	if got := s.Downsample(termenv.Ascii); !got.IsZero() || got.Render("a") != NewSGR().Hyperlink("http://x").Render("a") {
+		t.Errorf("Ascii kept attributes or lost the link: %q", got.Render("a"))
+	}
+	if got := s.Downsample(termenv.ANSI).String(); got != "\x1b[1;91m" {
+		t.Errorf("ANSI downsample = %q, want %q", got, "\x1b[1;91m")
+	}
+}
+
+func TestParseSGRColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want SGRColor
+		err  bool
+	}{
+		{"#ff8000", RGB(255, 128, 0), false},
+		{"#F80", RGB(255, 136, 0), false},
+		{"9", SGRBrightRed, false},
+		{"208", Color256(208), false},
+		{"256", SGRColor{}, true},
+		{"-1", SGRColor{}, true},
+		{"#12345", SGRColor{}, true},
+		{"#gggggg", SGRColor{}, true},
+		{"red", SGRColor{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSGRColor(tt.in)
+			if (err != nil) != tt.err {
+				t.Fatalf("ParseSGRColor(%q) error = %v, want error %v", tt.in, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSGRColor(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -1 +1,363 @@
 package ui
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │           ANSI AWARE STRINGS             │ */
+/* ╰──────────────────────────────────────────╯ */
+//
+// Every function below measures text in terminal cells: escape sequences take
+// no room, wide runes (CJK, emoji, flags like the ones GetLanguageFlag
+// returns) take two and combining marks stay glued to their base. Cutting a
+// styled string never splits an escape sequence and keeps the styles that
+// were active at the cut, closing them at the end of each piece.
+
+// segment is one escape sequence, control character or grapheme cluster.
+type segment struct {
+	text  string
+	width int
+	kind  segmentKind
+}
+
+type segmentKind uint8
+
+const (
+	segGrapheme segmentKind = iota
+	segEscape
+	segControl
+)
+
+// segments splits s into escape sequences, control characters and grapheme
+// clusters.
+func segments(s string) []segment {
+	var segs []segment
+	state := -1
+	for len(s) > 0 {
+		switch c := s[0]; {
+		case c == 0x1b:
+			n := escapeLen(s)
+			segs = append(segs, segment{text: s[:n], kind: segEscape})
+			s = s[n:]
+			state = -1
+		case c < 0x20 || c == 0x7f:
+			segs = append(segs, segment{text: s[:1], kind: segControl})
+			s = s[1:]
+			state = -1
+		default:
+			var (
+				cluster string
+				width   int
+			)
+			cluster, s, width, state = uniseg.FirstGraphemeClusterInString(s, state)
+			segs = append(segs, segment{text: cluster, width: width})
+		}
+	}
+	return segs
+}
+
+// escapeLen returns the length of the escape sequence s starts with.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[': // CSI: parameters and intermediates, then a final byte.
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']', 'P', 'X', '^', '_': // OSC, DCS, SOS, PM, APC: up to BEL or ST.
+		for i := 2; i < len(s); i++ {
+			if s[i] == 0x07 {
+				return i + 1
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+	return len(s)
+}
+
+// styleState follows the SGR attributes and hyperlink that are active at a
+// given point of a string.
+type styleState struct {
+	sgr  []string
+	link string
+}
+
+func (st *styleState) update(seq string) {
+	switch {
+	case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m"):
+		if params := seq[2 : len(seq)-1]; params == "" || params == "0" {
+			st.sgr = st.sgr[:0]
+		} else {
+			st.sgr = append(st.sgr, seq)
+		}
+	case strings.HasPrefix(seq, "\x1b]8;"):
+		// OSC 8 ; params ; URI ST. An empty URI closes the link.
+		body := strings.TrimSuffix(strings.TrimSuffix(seq[4:], "\x07"), "\x1b\\")
+		if i := strings.IndexByte(body, ';'); i >= 0 && body[i+1:] != "" {
+			st.link = seq
+		} else {
+			st.link = ""
+		}
+	}
+}
+
+// open returns the sequences that bring the state back after a reset.
+func (st *styleState) open() string {
+	return st.link + strings.Join(st.sgr, "")
+}
+
+// close returns the sequences that end the state.
+func (st *styleState) close() string {
+	var s string
+	if len(st.sgr) > 0 {
+		s += SGRReset
+	}
+	if st.link != "" {
+		s += "\x1b]8;;\x1b\\"
+	}
+	return s
+}
+
+// StringWidth returns how many cells s takes in a terminal.
+func StringWidth(s string) int {
+	w := 0
+	for _, seg := range segments(s) {
+		w += seg.width
+	}
+	return w
+}
+
+// cut returns the cells [left, right) of s with the styles active at left
+// reopened, plus the sequences closing whatever is still active at right.
+// A wide grapheme straddling either edge is replaced by spaces so the piece
+// is exactly right-left cells wide when s is wide enough.
+func cut(s string, left, right int) (string, string) {
+	var (
+		sb     strings.Builder
+		st     styleState
+		col    int
+		opened bool
+	)
+	for _, seg := range segments(s) {
+		if col >= right {
+			break
+		}
+		if seg.kind != segGrapheme {
+			st.update(seg.text)
+			if opened {
+				sb.WriteString(seg.text)
+			}
+			continue
+		}
+		start, end := col, col+seg.width
+		col = end
+		if end <= left && seg.width > 0 || start < left && seg.width == 0 {
+			continue
+		}
+		if !opened {
+			sb.WriteString(st.open())
+			opened = true
+		}
+		switch {
+		case start < left:
+			sb.WriteString(strings.Repeat(" ", min(end, right)-left))
+		case end > right:
+			sb.WriteString(strings.Repeat(" ", right-start))
+		default:
+			sb.WriteString(seg.text)
+		}
+	}
+	if !opened {
+		return "", ""
+	}
+	return sb.String(), st.close()
+}
+
+// StripANSI returns s without its escape sequences.
+func StripANSI(s string) string {
+	var sb strings.Builder
+	for _, seg := range segments(s) {
+		if seg.kind != segEscape {
+			sb.WriteString(seg.text)
+		}
+	}
+	return sb.String()
+}
+
+// SliceColumns returns the cells [left, right) of s, keeping the styles
+// active at left.
+func SliceColumns(s string, left, right int) string {
+	if right <= left {
+		return ""
+	}
+	body, closing := cut(s, max(left, 0), right)
+	return body + closing
+}
+
+// Truncate shortens s to width cells, ending it with tail when it is cut.
+// The tail keeps the style of the text it replaces.
+func Truncate(s string, width int, tail string) string {
+	if StringWidth(s) <= width {
+		return s
+	}
+	tw := StringWidth(tail)
+	if width <= tw {
+		return SliceColumns(tail, 0, width)
+	}
+	body, closing := cut(s, 0, width-tw)
+	return body + tail + closing
+}
+
+// TruncateLeft shortens s to width cells by dropping its beginning, starting
+// it with head when it is cut.
+func TruncateLeft(s string, width int, head string) string {
+	sw := StringWidth(s)
+	if sw <= width {
+		return s
+	}
+	hw := StringWidth(head)
+	if width <= hw {
+		return SliceColumns(head, 0, width)
+	}
+	return head + SliceColumns(s, sw-(width-hw), sw)
+}
+
+// TruncateMiddle shortens s to width cells by replacing its middle with
+// ellipsis, which is handy for paths and identifiers.
+func TruncateMiddle(s string, width int, ellipsis string) string {
+	sw := StringWidth(s)
+	if sw <= width {
+		return s
+	}
+	ew := StringWidth(ellipsis)
+	if width <= ew {
+		return SliceColumns(ellipsis, 0, width)
+	}
+	avail := width - ew
+	right := avail / 2
+	left := avail - right
+	body, closing := cut(s, 0, left)
+	return body + ellipsis + closing + SliceColumns(s, sw-right, sw)
+}
+
+// PadRight appends spaces to s until it is width cells wide.
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-StringWidth(s), 0))
+}
+
+// PadLeft prepends spaces to s until it is width cells wide.
+func PadLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-StringWidth(s), 0)) + s
+}
+
+// PadCenter surrounds s with spaces until it is width cells wide. The odd
+// space, if any, goes to the right.
+func PadCenter(s string, width int) string {
+	gap := max(width-StringWidth(s), 0)
+	return strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2)
+}
+
+// Wrap breaks s into lines of at most width cells, at spaces when possible
+// and inside words longer than a line otherwise. Existing line breaks are
+// kept, and the styles active at each break continue on the next line.
+func Wrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	w := wrapper{width: width}
+	var word []segment
+	wordWidth := 0
+	flush := func() {
+		w.word(word, wordWidth)
+		word, wordWidth = word[:0], 0
+	}
+	for _, seg := range segments(s) {
+		switch {
+		case seg.kind == segControl && seg.text == "\n":
+			flush()
+			w.newline()
+		case seg.kind == segGrapheme && seg.text == " ":
+			flush()
+			w.space()
+		default:
+			word = append(word, seg)
+			wordWidth += seg.width
+		}
+	}
+	flush()
+	return w.String()
+}
+
+// wrapper builds the lines of Wrap.
+type wrapper struct {
+	width  int
+	lines  []string
+	line   strings.Builder
+	col    int
+	spaces int  // Spaces waiting to be written before the next word.
+	soft   bool // The line was started by Wrap, not by the input.
+	st     styleState
+}
+
+// newline ends the line where the input does.
+func (w *wrapper) newline() {
+	w.line.WriteString(w.st.close())
+	w.lines = append(w.lines, w.line.String())
+	w.line.Reset()
+	w.line.WriteString(w.st.open())
+	w.col, w.spaces, w.soft = 0, 0, false
+}
+
+// wrap ends the line to fit the width.
+func (w *wrapper) wrap() {
+	w.newline()
+	w.soft = true
+}
+
+// space adds a space before the next word. Spaces starting an input line
+// are kept as its indentation; those left at a wrap are dropped.
+func (w *wrapper) space() {
+	if w.col > 0 || !w.soft {
+		w.spaces++
+	}
+}
+
+func (w *wrapper) word(segs []segment, width int) {
+	if len(segs) == 0 {
+		return
+	}
+	if w.col > 0 && w.col+w.spaces+width > w.width {
+		w.wrap()
+	}
+	w.line.WriteString(strings.Repeat(" ", w.spaces))
+	w.col += w.spaces
+	w.spaces = 0
+	for _, seg := range segs {
+		if seg.kind != segGrapheme {
+			w.st.update(seg.text)
+			w.line.WriteString(seg.text)
+			continue
+		}
+		if w.col > 0 && w.col+seg.width > w.width {
+			w.wrap()
+		}
+		w.line.WriteString(seg.text)
+		w.col += seg.width
+	}
+}
+
+func (w *wrapper) String() string {
+	lines := append(w.lines, w.line.String())
+	return strings.Join(lines, "\n")
+}
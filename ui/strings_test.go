@@ -0,0 +1,144 @@
+package ui
+
+import "testing"
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"ascii", "abc", 3},
+		{"empty", "", 0},
+		{"cjk", "日本語", 6},
+		{"emoji", "👍", 2},
+		{"zwj sequence", "👨‍👩‍👧", 2},
+		{"flag", "🇪🇸", 2},
+		{"combining mark", "é", 1},
+		{"sgr", "\x1b[31mred\x1b[0m", 3},
+		{"hyperlink", "\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringWidth(tt.s); got != tt.want {
+				t.Errorf("StringWidth(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	got := StripANSI("\x1b[1;31mhi\x1b[0m \x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\")
+	if want := "hi link"; got != want {
+		t.Errorf("StripANSI = %q, want %q", got, want)
+	}
+}
+
+func TestSliceColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		left, right int
+		want        string
+	}{
+		{"ascii", "abcdef", 1, 4, "bcd"},
+		{"empty range", "abc", 2, 2, ""},
+		{"past the end", "abc", 1, 10, "bc"},
+		{"cjk", "日本語", 2, 4, "本"},
+		{"cjk split on both edges", "日本語", 1, 5, " 本 "},
+		{"emoji", "a👍b", 1, 3, "👍"},
+		{"styled", "\x1b[32mgreen\x1b[0m", 1, 3, "\x1b[32mre\x1b[0m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SliceColumns(tt.s, tt.left, tt.right); got != tt.want {
+				t.Errorf("SliceColumns(%q, %d, %d) = %q, want %q", tt.s, tt.left, tt.right, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "short", 10, "short"},
+		{"ascii", "hello world", 8, "hello w…"},
+		{"cjk", "日本語テキスト", 7, "日本語…"},
+		{"cjk split", "日本語テキスト", 6, "日本 …"},
+		{"emoji", "👍👍👍", 4, "👍 …"},
+		{"styled", "\x1b[1mbold text\x1b[0m", 6, "\x1b[1mbold …\x1b[0m"},
+		{"narrower than tail", "hello", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.s, tt.width, "…")
+			if got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+			if w := StringWidth(got); tt.width > 0 && StringWidth(tt.s) > tt.width && w != tt.width {
+				t.Errorf("Truncate(%q, %d) is %d cells wide", tt.s, tt.width, w)
+			}
+		})
+	}
+}
+
+func TestTruncateLeftMiddle(t *testing.T) {
+	if got, want := TruncateLeft("日本語テキスト", 7, "…"), "…キスト"; got != want {
+		t.Errorf("TruncateLeft = %q, want %q", got, want)
+	}
+	if got, want := TruncateMiddle("/home/user/projects/app", 12, "…"), "/home/…s/app"; got != want {
+		t.Errorf("TruncateMiddle = %q, want %q", got, want)
+	}
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"right cjk", PadRight("日本", 6), "日本  "},
+		{"right too wide", PadRight("abcdef", 3), "abcdef"},
+		{"left styled", PadLeft("\x1b[1mx\x1b[0m", 3), "  \x1b[1mx\x1b[0m"},
+		{"center", PadCenter("ab", 5), " ab  "},
+		{"center emoji", PadCenter("👍", 4), " 👍 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "one two", 10, "one two"},
+		{"at spaces", "one two three four", 9, "one two\nthree\nfour"},
+		{"long word", "superlongword", 5, "super\nlongw\nord"},
+		{"keeps line breaks", "a\nb c", 10, "a\nb c"},
+		{"keeps indentation", "    indented code line\n  - item two here", 40, "    indented code line\n  - item two here"},
+		{"drops spaces at wraps", "  one two three four", 8, "  one\ntwo\nthree\nfour"},
+		{"cjk", "日本語のテキスト", 6, "日本語\nのテキ\nスト"},
+		{"emoji", "👍👍👍", 4, "👍👍\n👍"},
+		{"styled", "\x1b[31mred words here\x1b[0m", 5, "\x1b[31mred\x1b[0m\n\x1b[31mwords\x1b[0m\n\x1b[31mhere\x1b[0m"},
+		{"no width", "a b", 0, "a b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.s, tt.width); got != tt.want {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"hash/fnv"
 	"image/color"
-	"net/http"
-	"strconv"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/logrusorgru/aurora"
@@ -66,197 +64,141 @@ var TitleCaser = cases.Title(language.Und)
 
 // ANSI Color definitions.
 var (
-	Red     = "\033[31m"
-	Green   = "\033[32m"
-	Yellow  = "\033[33m"
-	Blue    = "\033[34m"
-	Magenta = "\033[35m"
-	Cyan    = "\033[36m"
-	White   = "\033[37m"
-	Black   = "\033[30m"
-	Gray    = "\033[37m"
-
-	DarkYellow  = "\033[33;43m"
-	DarkBlue    = "\033[34;44m"
-	DarkRed     = "\033[31;41m"
-	DarkGreen   = "\033[32;42m"
-	DarkCyan    = "\033[36;46m"
-	DarkMagenta = "\033[35;45m"
-	DarkWhite   = "\033[37;47m"
+	Red     = NewSGR().Fg(SGRRed).String()
+	Green   = NewSGR().Fg(SGRGreen).String()
+	Yellow  = NewSGR().Fg(SGRYellow).String()
+	Blue    = NewSGR().Fg(SGRBlue).String()
+	Magenta = NewSGR().Fg(SGRMagenta).String()
+	Cyan    = NewSGR().Fg(SGRCyan).String()
+	White   = NewSGR().Fg(SGRWhite).String()
+	Black   = NewSGR().Fg(SGRBlack).String()
+	Gray    = NewSGR().Fg(SGRBrightBlack).String()
+
+	// Foreground and background in the same color.
+	DarkYellow  = NewSGR().Fg(SGRYellow).Bg(SGRYellow).String()
+	DarkBlue    = NewSGR().Fg(SGRBlue).Bg(SGRBlue).String()
+	DarkRed     = NewSGR().Fg(SGRRed).Bg(SGRRed).String()
+	DarkGreen   = NewSGR().Fg(SGRGreen).Bg(SGRGreen).String()
+	DarkCyan    = NewSGR().Fg(SGRCyan).Bg(SGRCyan).String()
+	DarkMagenta = NewSGR().Fg(SGRMagenta).Bg(SGRMagenta).String()
+	DarkWhite   = NewSGR().Fg(SGRWhite).Bg(SGRWhite).String()
 
 	// ANSI escape codes for formatting
-	Reset           = "\033[0m" // Reset to default color
-	Bold            = "\033[1m"
-	BoldRed         = "\033[1;31m"
-	BoldBlue        = "\033[1;34m"
-	BoldCyan        = "\033[1;37m"
-	BoldOrange      = "\033[1;33m"
-	BoldGreen       = "\033[1;32m"
-	BoldYellow      = "\033[1;33m"
-	BoldMagenta     = "\033[1;35m"
-	Underline       = "\033[4m"
-	UnderlineRed    = "\033[4;31m"
-	UnderlineBlue   = "\033[4;34m"
-	UnderlineOrange = "\033[4;33m"
-	UnderlineGreen  = "\033[4;32m"
-	UnderlineYellow = "\033[4;33m"
-	BoldDarkYellow  = "\033[1;33;43m"
+	Reset           = SGRReset // Reset to default color
+	Bold            = NewSGR().Bold().String()
+	BoldRed         = NewSGR().Bold().Fg(SGRRed).String()
+	BoldBlue        = NewSGR().Bold().Fg(SGRBlue).String()
+	BoldCyan        = NewSGR().Bold().Fg(SGRCyan).String()
+	BoldOrange      = NewSGR().Bold().Fg(SGRYellow).String() // No orange among the 16 ANSI colors.
+	BoldGreen       = NewSGR().Bold().Fg(SGRGreen).String()
+	BoldYellow      = NewSGR().Bold().Fg(SGRYellow).String()
+	BoldMagenta     = NewSGR().Bold().Fg(SGRMagenta).String()
+	Underline       = NewSGR().Underline().String()
+	UnderlineRed    = NewSGR().Underline().Fg(SGRRed).String()
+	UnderlineBlue   = NewSGR().Underline().Fg(SGRBlue).String()
+	UnderlineOrange = NewSGR().Underline().Fg(SGRYellow).String()
+	UnderlineGreen  = NewSGR().Underline().Fg(SGRGreen).String()
+	UnderlineYellow = NewSGR().Underline().Fg(SGRYellow).String()
+	BoldDarkYellow  = NewSGR().Bold().Fg(SGRYellow).Bg(SGRYellow).String()
 )
 
+// defaultTheme backs the package-level style globals below.
+var defaultTheme = DefaultTheme()
+
 // HEX Color definitions.
 var (
-	HEX = map[string]string{
-		"Green":   "#71fd4b",
-		"Red":     "#fd4b4b",
-		"Yellow":  "#fdcc4b",
-		"Blue":    "#4b4bfd",
-		"Magenta": "#fd4bfd",
-		"Cyan:":   "#4bffd9",
-		"White":   "#ffffff",
-		"Black":   "#000000",
-		"Gray":    "#383838",
-
-		"DarkYellow":  "#fdcc4b",
-		"DarkBlue":    "#4b4bfd",
-		"DarkRed":     "#fd4b4b",
-		"DarkGreen":   "#71fd4b",
-		"DarkCyan":    "#74ade9",
-		"DarkMagenta": "#fd4bfd",
-		"DarkWhite":   "#ffffff",
-
-		"LightMagenta": "#f9d5ff",
-
-		"TxeoCalculatorGreen": "#43BF6D",
-		"GooglePlusRed":       "#c27a71",
-		"GoogleBlue":          "#78a4ea",
-		"AndroidGreen":        "#93d396",
-	}
+	HEX = defaultTheme.Palette.HEX
 )
 
 // Style definitions.
+//
+// These are thin aliases of DefaultTheme kept for compatibility. Prefer
+// passing a *Theme around: reassigning one of these only affects the code
+// that reads the global, never other themes.
 var (
 	// General.
-	PanelBackgroundColor = lipgloss.AdaptiveColor{Light: "#71fd4b", Dark: "#031935ff"}
-	Subtle               = lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#383838"}
-	Highlight            = lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"}
-	Special              = lipgloss.AdaptiveColor{Light: "#43BF6D", Dark: "#73F59F"}
-	ErrorColor           = lipgloss.AdaptiveColor{Light: "#BF616A", Dark: "#F07178"}
-	Divider              = lipgloss.NewStyle().SetString("•").Padding(0, 1).Foreground(Subtle).String()
-	Url                  = lipgloss.NewStyle().Foreground(Special).Render
+	PanelBackgroundColor = defaultTheme.Palette.PanelBackground
+	Subtle               = defaultTheme.Palette.Subtle
+	Highlight            = defaultTheme.Palette.Highlight
+	Special              = defaultTheme.Palette.Special
+	ErrorColor           = defaultTheme.Palette.ErrorColor
+	Divider              = defaultTheme.Divider()
+	Url                  = defaultTheme.UrlStyle.Render
 
 	// Page.
-	DocStyle = lipgloss.NewStyle().Padding(1, 1).Margin(0).Align(lipgloss.Center)
+	DocStyle = defaultTheme.DocStyle
 
 	// Tabs.
-	ActiveTabBorder = lipgloss.Border{
-		Top:         "─",
-		Bottom:      " ",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "╭",
-		TopRight:    "╮",
-		BottomLeft:  "┘",
-		BottomRight: "└",
-	}
-	TabBorder = lipgloss.Border{
-		Top:         "─",
-		Bottom:      "─",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "╭",
-		TopRight:    "╮",
-		BottomLeft:  "┴",
-		BottomRight: "┴",
-	}
-	PanelBorder = lipgloss.Border{
-		Top:         "─",
-		Bottom:      "─",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "╭",
-		TopRight:    "╮",
-		BottomLeft:  "╰",
-		BottomRight: "╯",
-	}
+	ActiveTabBorder = defaultTheme.ActiveTabBorder
+	TabBorder       = defaultTheme.TabBorder
+	PanelBorder     = defaultTheme.PanelBorder
 
-	RegularTab        = lipgloss.NewStyle().Border(TabBorder, true).BorderForeground(Highlight).Padding(0, 1)
+	RegularTab        = defaultTheme.RegularTab
 	RegularPanelStile = RegularTab
-	ActiveTab         = RegularTab.Border(ActiveTabBorder, true).Bold(true)
-	TabGap            = RegularTab.BorderTop(false).BorderLeft(false).BorderRight(false)
+	ActiveTab         = defaultTheme.ActiveTab
+	TabGap            = defaultTheme.TabGap
 
 	// Paragraphs/History.
-	PanelStyle = lipgloss.NewStyle().Border(PanelBorder).Align(lipgloss.Center).Foreground(lipgloss.Color("#FAFAFA")).Margin(0, 1).Padding(1, 2)
+	PanelStyle = defaultTheme.PanelStyle
 
 	// Crear un estilo base
-	ActiveStyle    = lipgloss.NewStyle().BorderForeground(lipgloss.Color("120")).Align(lipgloss.Center).BorderStyle(lipgloss.DoubleBorder())
-	NonActiveStyle = lipgloss.NewStyle().BorderForeground(lipgloss.Color("240")).Align(lipgloss.Center).UnsetBorderStyle()
-	// NonBorderedActiveStyle = lipgloss.NewStyle().BorderForeground(lipgloss.Color("240")).BorderStyle(lipgloss.HiddenBorder())
+	ActiveStyle    = defaultTheme.ActiveStyle
+	NonActiveStyle = defaultTheme.NonActiveStyle
 
 	// Title.
-	TitleStyle = lipgloss.NewStyle().Align(lipgloss.Left).Foreground(lipgloss.Color("#43BF6D")).Bold(true).Margin(2, 0, 0, 0)
-	DescStyle  = lipgloss.NewStyle().Align(lipgloss.Left).MarginTop(1).Foreground(lipgloss.Color("#874BFD")).Inline(true)
-	InfoStyle  = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderTop(true).BorderForeground(Subtle)
+	TitleStyle = defaultTheme.TitleStyle
+	DescStyle  = defaultTheme.DescStyle
+	InfoStyle  = defaultTheme.InfoStyle
 
 	// Dialog.
-	DialogBoxStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("#874BFD")).Padding(1, 0).BorderTop(true).BorderLeft(true).BorderRight(true).BorderBottom(true)
-	ButtonStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFF7DB")).Background(lipgloss.Color("#888B7E")).Padding(0, 3).MarginTop(1)
-	ActiveButtonStyle = ButtonStyle.Foreground(lipgloss.Color("#FFF7DB")).Background(lipgloss.Color("#e581a6")).MarginRight(2).Underline(true)
-	WidthInfoStyle    = lipgloss.NewStyle().Background(lipgloss.Color("#90747e")).Bold(true).Foreground(lipgloss.Color("#ffffff"))
+	DialogBoxStyle    = defaultTheme.DialogBoxStyle
+	ButtonStyle       = defaultTheme.ButtonStyle
+	ActiveButtonStyle = defaultTheme.ActiveButtonStyle
+	WidthInfoStyle    = defaultTheme.WidthInfoStyle
 
 	// List.
-	ListExample = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, true, false, false).BorderForeground(Subtle).MarginRight(2).Height(8)
-	ListHeader  = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderBottom(true).BorderForeground(Subtle).MarginRight(2).Render
-	ListItem    = lipgloss.NewStyle().PaddingLeft(2).Render
-	CheckMark   = lipgloss.NewStyle().SetString("✓").Foreground(Special).PaddingRight(1).String()
-	ListDone    = func(s string) string {
-		return CheckMark + lipgloss.NewStyle().Strikethrough(true).Foreground(lipgloss.AdaptiveColor{Light: "#969B86", Dark: "#696969"}).Render(s)
-	}
+	ListExample = defaultTheme.ListExample
+	ListHeader  = defaultTheme.ListHeaderStyle.Render
+	ListItem    = defaultTheme.ListItemStyle.Render
+	CheckMark   = defaultTheme.CheckMark()
+	ListDone    = defaultTheme.ListDone
 
 	// Status Bar.
-	StatusNugget                 = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFDF5")).Padding(0, 1)
-	StatusBarStyle               = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#343433", Dark: "#C1C6B2"}).Background(lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#353533"})
-	StatusStyle                  = lipgloss.NewStyle().Inherit(StatusBarStyle).Foreground(lipgloss.Color("#FFFDF5")).Background(lipgloss.Color("#3f8edd")).Bold(true).Padding(0, 1).MarginRight(1)
-	CurrentBedFilesBoardStyle    = StatusNugget.Foreground(lipgloss.AdaptiveColor{Dark: "#343433", Light: "#C1C6B2"}).Background(lipgloss.Color("#e4f0f8")).Align(lipgloss.Right).Bold(true)
-	StatusText                   = lipgloss.NewStyle().Inherit(StatusBarStyle)
-	CurrentBedFilesListStyle     = StatusNugget.Background(lipgloss.Color("#377ec4"))
-	CurrentBedFilesUsernameStyle = StatusNugget.Background(lipgloss.Color("#4988c8ae"))
+	StatusNugget   = defaultTheme.StatusNugget
+	StatusBarStyle = defaultTheme.StatusBarStyle
+	StatusStyle    = defaultTheme.StatusStyle
+	StatusText     = defaultTheme.StatusText
 
-	// Hours Distribution.
-	HoursDistributionStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Background(lipgloss.Color("#b36969")).
-				Margin(0, 1).
-				Padding(0, 5).Align(lipgloss.Center)
+	// Deprecated: use Theme.StatusStrong, StatusInfo and StatusPrimary, or
+	// widgets.StatusBar.
+	CurrentBedFilesBoardStyle    = defaultTheme.StatusStrong
+	CurrentBedFilesListStyle     = defaultTheme.StatusInfo
+	CurrentBedFilesUsernameStyle = defaultTheme.StatusPrimary
 
-	CalendarHoursDistributionStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("#FFFFFF")).
-					Background(lipgloss.Color("#b36969"))
+	// Hours Distribution.
+	HoursDistributionStyle         = defaultTheme.HoursDistributionStyle
+	CalendarHoursDistributionStyle = defaultTheme.CalendarHoursDistributionStyle
 
 	// General styles
 	// Colores inspirados en BedFiles
-	BedFilesBlue       = "#0079BF"
-	BedFilesBackground = "#43BF6D"
+	BedFilesBlue       = defaultTheme.Palette.BrandForeground.Dark
+	BedFilesBackground = defaultTheme.Palette.BrandBackground.Dark
 
 	// Estilo principal para el contenido
-	Style = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(BedFilesBlue)).
-		Background(lipgloss.Color(BedFilesBackground)).
-		Padding(1, 2).
-		Margin(1).
-		Align(lipgloss.Center)
+	Style = defaultTheme.BrandStyle
 
 	// Estilo para el texto del usuario y sugerencias
-	UserInputStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))  // Blanco brillante
-	SuggestionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244")) // Gris claro
+	UserInputStyle  = defaultTheme.UserInputStyle  // Blanco brillante
+	SuggestionStyle = defaultTheme.SuggestionStyle // Gris claro
 
 	// Bordered styles
-	BaseBorderedStyle    = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).Align(lipgloss.Left).Padding(1, 4)
-	CompactBorderedStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("#c8c8c8")).Align(lipgloss.Left).Padding(0, 4)
+	BaseBorderedStyle    = defaultTheme.BaseBorderedStyle
+	CompactBorderedStyle = defaultTheme.CompactBorderedStyle
 
 	// Dialog and Base styles
-	BaseStyle       = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240"))
-	NoBorderedStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderTop(false).BorderLeft(false).BorderRight(false).BorderBottom(false)
-	DialogStyle     = lipgloss.NewStyle().Width(50).Align(lipgloss.Center)
+	BaseStyle       = defaultTheme.BaseStyle
+	NoBorderedStyle = defaultTheme.NoBorderedStyle
+	DialogStyle     = defaultTheme.DialogStyle
 )
 
 func GetStringInColor(color string, s string) string {
@@ -268,20 +210,12 @@ func GetStringInColor(color string, s string) string {
 /* │         BUBBLE TABLE DEFINITION          │ */
 /* ╰──────────────────────────────────────────╯ */
 var (
-	StyleSubtle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888"))
-
-	StyleBase = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#a7a")).
-			BorderForeground(lipgloss.Color("#a38")).
-			Align(lipgloss.Left).Padding(0, 1)
-
-	StyleBaseRow = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#a7a")).
-			BorderForeground(lipgloss.Color("#a38")).
-			Align(lipgloss.Left).Padding(0, 1)
-	StyleCentered = lipgloss.NewStyle().Align(lipgloss.Center).Padding(0, 1)
-	StyleLeft     = lipgloss.NewStyle().Align(lipgloss.Left).Margin(0, 1)
-	StyleRight    = lipgloss.NewStyle().Align(lipgloss.Right).Padding(0, 1)
+	StyleSubtle   = defaultTheme.StyleSubtle
+	StyleBase     = defaultTheme.StyleBase
+	StyleBaseRow  = defaultTheme.StyleBaseRow
+	StyleCentered = defaultTheme.StyleCentered
+	StyleLeft     = defaultTheme.StyleLeft
+	StyleRight    = defaultTheme.StyleRight
 )
 
 const (
@@ -304,43 +238,40 @@ const (
 
 // General stuff for styling the view
 var (
-	KeywordStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("211"))
-	SubtleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	TicksStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("79"))
-	CheckboxStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
-	ProgressEmpty = SubtleStyle.Render(ProgressEmptyChar)
-	DotStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("236")).Render(DotChar)
-	MainStyle     = lipgloss.NewStyle().MarginLeft(2)
+	KeywordStyle  = defaultTheme.KeywordStyle
+	SubtleStyle   = defaultTheme.SubtleStyle
+	TicksStyle    = defaultTheme.TicksStyle
+	CheckboxStyle = defaultTheme.CheckboxStyle
+	ProgressEmpty = defaultTheme.SubtleStyle.Render(ProgressEmptyChar)
+	DotStyle      = defaultTheme.DotStyle.Render(DotChar)
+	MainStyle     = defaultTheme.MainStyle
 
 	// Gradient colors we'll use for the progress bar
-	Ramp = makeRampStyles("#B14FFF", "#00FFA3", ProgressBarWidth)
+	Ramp = defaultTheme.Ramp
 )
 
 /* ╭──────────────────────────────────────────╮ */
 /* │                  TRELLO                  │ */
 /* ╰──────────────────────────────────────────╯ */
 var (
-	// Status Bar.
-	CurrentTrelloBoardStyle    = StatusNugget.Foreground(lipgloss.AdaptiveColor{Dark: "#343433", Light: "#C1C6B2"}).Background(lipgloss.Color("#e4f0f8")).Align(lipgloss.Right).Bold(true)
-	CurrentTrelloListStyle     = StatusNugget.Background(lipgloss.Color("#377ec4"))
-	CurrentTrelloUsernameStyle = StatusNugget.Background(lipgloss.Color("#4988c8ae"))
+	// Deprecated: use Theme.StatusStrong, StatusInfo and StatusPrimary, or
+	// widgets.StatusBar.
+	CurrentTrelloBoardStyle    = defaultTheme.StatusStrong
+	CurrentTrelloListStyle     = defaultTheme.StatusInfo
+	CurrentTrelloUsernameStyle = defaultTheme.StatusPrimary
 	// General styles
 	// Colores inspirados en Trello
 	TrelloBlue       = "#0079BF"
 	TrelloBackground = "#EBECF0"
 
-	ViewportTitleStyle = func() lipgloss.Style {
-		b := lipgloss.RoundedBorder()
-		b.Right = "├"
-		return lipgloss.NewStyle().BorderStyle(b).Padding(0, 1)
-	}()
+	ViewportTitleStyle = defaultTheme.ViewportTitleStyle
 )
 
 var (
 	Term      = termenv.EnvColorProfile()
-	SubtleII  = makeFgStyle("241")
-	Dot       = ColorFg(" • ", "236")
-	HelpStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render
+	SubtleII  = defaultTheme.SubtleStyle.Render
+	Dot       = defaultTheme.DotStyle.Render(DotChar)
+	HelpStyle = defaultTheme.HelpStyle.Render
 )
 
 func ColorToHex(c colorful.Color) string {
@@ -485,17 +416,5 @@ func hexToRGB(hex string) (uint8, uint8, uint8) {
 	return r, g, b
 }
 func GetStatusColor(status int) aurora.Value {
-	var statusColor aurora.Value
-	statusSpaced := " " + strconv.Itoa(status) + " "
-	switch {
-	case status >= http.StatusInternalServerError:
-		statusColor = aurora.BgRed(aurora.White(statusSpaced))
-	case status >= http.StatusBadRequest:
-		statusColor = aurora.BgYellow(aurora.Black(statusSpaced))
-	case status >= http.StatusMultipleChoices:
-		statusColor = aurora.BgCyan(aurora.Black(statusSpaced))
-	default:
-		statusColor = aurora.BgGreen(aurora.Black(statusSpaced))
-	}
-	return statusColor
+	return defaultTheme.StatusColor(status)
 }
@@ -12,6 +12,8 @@ import (
 func getColorsSampleHoursUI() string {
 
 	var sb strings.Builder
+	theme := txui.DefaultTheme()
+	style := theme.HoursDistributionStyle
 	// Lista de horas trabajadas
 	hoursWorked := []float64{9.0, 8.5, 8.0, 7.5, 7.0, 6.5, 6.0, 5.5, 5.0, 4.5, 4.0, 3.5, 3.0, 2.5, 2.0, 1.5, 1.0, 0.5}
 
@@ -22,9 +24,9 @@ func getColorsSampleHoursUI() string {
 			colorForHours = "#ff0000"
 		}
 		if hours < 0.5 {
-			txui.HoursDistributionStyle = txui.HoursDistributionStyle.Foreground(lipgloss.Color("#000000"))
+			style = style.Foreground(lipgloss.Color("#000000"))
 		}
-		sb.WriteString("\n" + txui.HoursDistributionStyle.Background(lipgloss.Color(colorForHours)).Render(hoursFormatted))
+		sb.WriteString("\n" + style.Background(lipgloss.Color(colorForHours)).Render(hoursFormatted))
 	}
 
 	return sb.String()
@@ -0,0 +1,442 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Palette holds every named color a Theme builds its styles from.
+type Palette struct {
+	// General.
+	Highlight       lipgloss.AdaptiveColor
+	Subtle          lipgloss.AdaptiveColor
+	Special         lipgloss.AdaptiveColor
+	ErrorColor      lipgloss.AdaptiveColor
+	PanelBackground lipgloss.AdaptiveColor
+	PanelForeground lipgloss.AdaptiveColor
+	Done            lipgloss.AdaptiveColor
+	ActiveBorder    lipgloss.AdaptiveColor
+	InactiveBorder  lipgloss.AdaptiveColor
+	CompactBorder   lipgloss.AdaptiveColor
+
+	// Title.
+	Title       lipgloss.AdaptiveColor
+	Description lipgloss.AdaptiveColor
+
+	// Dialog.
+	DialogBorder           lipgloss.AdaptiveColor
+	ButtonForeground       lipgloss.AdaptiveColor
+	ButtonBackground       lipgloss.AdaptiveColor
+	ActiveButtonBackground lipgloss.AdaptiveColor
+	WidthInfoForeground    lipgloss.AdaptiveColor
+	WidthInfoBackground    lipgloss.AdaptiveColor
+
+	// Status Bar.
+	StatusBarForeground   lipgloss.AdaptiveColor
+	StatusBarBackground   lipgloss.AdaptiveColor
+	StatusNugget          lipgloss.AdaptiveColor
+	StatusAccent          lipgloss.AdaptiveColor
+	StatusBoardForeground lipgloss.AdaptiveColor
+	StatusBoardBackground lipgloss.AdaptiveColor
+	StatusList            lipgloss.AdaptiveColor
+	StatusUsername        lipgloss.AdaptiveColor
+
+	// Hours Distribution.
+	HoursForeground lipgloss.AdaptiveColor
+	HoursBackground lipgloss.AdaptiveColor
+
+	// Main content (BedFiles/Trello).
+	BrandForeground lipgloss.AdaptiveColor
+	BrandBackground lipgloss.AdaptiveColor
+
+	// User input and suggestions.
+	UserInput  lipgloss.AdaptiveColor
+	Suggestion lipgloss.AdaptiveColor
+
+	// Bubble table.
+	TableSubtle     lipgloss.AdaptiveColor
+	TableForeground lipgloss.AdaptiveColor
+	TableBorder     lipgloss.AdaptiveColor
+
+	// Bubble views.
+	Keyword    lipgloss.AdaptiveColor
+	SubtleText lipgloss.AdaptiveColor
+	Ticks      lipgloss.AdaptiveColor
+	Checkbox   lipgloss.AdaptiveColor
+	Dot        lipgloss.AdaptiveColor
+	RampStart  string
+	RampEnd    string
+
+	// Named colors used by GetStringInColor.
+	HEX map[string]string
+}
+
+// Theme owns every named style and color of the library. Build one with
+// NewTheme (or DefaultTheme) and pass it around instead of relying on the
+// package-level style globals, so different screens and apps can look
+// different without stepping on each other.
+type Theme struct {
+	Name    string
+	Tokens  Tokens
+	Palette Palette
+
+	// Borders.
+	ActiveTabBorder lipgloss.Border
+	TabBorder       lipgloss.Border
+	PanelBorder     lipgloss.Border
+
+	// General.
+	DividerStyle lipgloss.Style
+	UrlStyle     lipgloss.Style
+	DocStyle     lipgloss.Style
+
+	// Tabs.
+	RegularTab lipgloss.Style
+	ActiveTab  lipgloss.Style
+	TabGap     lipgloss.Style
+
+	// Paragraphs/History.
+	PanelStyle     lipgloss.Style
+	ActiveStyle    lipgloss.Style
+	NonActiveStyle lipgloss.Style
+
+	// Title.
+	TitleStyle lipgloss.Style
+	DescStyle  lipgloss.Style
+	InfoStyle  lipgloss.Style
+
+	// Dialog.
+	DialogBoxStyle    lipgloss.Style
+	ButtonStyle       lipgloss.Style
+	ActiveButtonStyle lipgloss.Style
+	WidthInfoStyle    lipgloss.Style
+	DialogStyle       lipgloss.Style
+
+	// List.
+	ListExample     lipgloss.Style
+	ListHeaderStyle lipgloss.Style
+	ListItemStyle   lipgloss.Style
+	CheckMarkStyle  lipgloss.Style
+	ListDoneStyle   lipgloss.Style
+
+	// Status Bar.
+	StatusNugget   lipgloss.Style
+	StatusBarStyle lipgloss.Style
+	StatusStyle    lipgloss.Style
+	StatusText     lipgloss.Style
+	StatusStrong   lipgloss.Style // Inverted, bold segment, like the current board.
+	StatusInfo     lipgloss.Style // Segment on the info color, like the current list.
+	StatusPrimary  lipgloss.Style // Segment on the primary color, like the user name.
+	// Deprecated: use StatusStrong.
+	CurrentBoardStyle lipgloss.Style
+	// Deprecated: use StatusInfo.
+	CurrentListStyle lipgloss.Style
+	// Deprecated: use StatusPrimary.
+	CurrentUsernameStyle           lipgloss.Style
+	ViewportTitleStyle             lipgloss.Style
+	HoursDistributionStyle         lipgloss.Style
+	CalendarHoursDistributionStyle lipgloss.Style
+
+	// Main content, inputs and bordered boxes.
+	BrandStyle           lipgloss.Style
+	UserInputStyle       lipgloss.Style
+	SuggestionStyle      lipgloss.Style
+	BaseBorderedStyle    lipgloss.Style
+	CompactBorderedStyle lipgloss.Style
+	BaseStyle            lipgloss.Style
+	NoBorderedStyle      lipgloss.Style
+
+	// Bubble table.
+	StyleSubtle   lipgloss.Style
+	StyleBase     lipgloss.Style
+	StyleBaseRow  lipgloss.Style
+	StyleCentered lipgloss.Style
+	StyleLeft     lipgloss.Style
+	StyleRight    lipgloss.Style
+
+	// Bubble views.
+	KeywordStyle  lipgloss.Style
+	SubtleStyle   lipgloss.Style
+	TicksStyle    lipgloss.Style
+	CheckboxStyle lipgloss.Style
+	DotStyle      lipgloss.Style
+	MainStyle     lipgloss.Style
+	HelpStyle     lipgloss.Style
+	Ramp          []lipgloss.Style
+
+	// Messages, on the Danger, Warning, Success and Info colors.
+	ErrorStyle   lipgloss.Style
+	WarningStyle lipgloss.Style
+	SuccessStyle lipgloss.Style
+	NoticeStyle  lipgloss.Style
+	ToastStyle   lipgloss.Style // Frame of a notification, bordered in the color of its level.
+
+	renderer *Renderer
+}
+
+// solid returns an AdaptiveColor that is the same on light and dark backgrounds.
+func solid(c string) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: c, Dark: c}
+}
+
+// DefaultPalette returns the palette of the default theme.
+func DefaultPalette() Palette {
+	return DefaultTokens().Palette()
+}
+
+// defaultHEX returns the named colors used by GetStringInColor.
+func defaultHEX() map[string]string {
+	return map[string]string{
+		"Green":   "#71fd4b",
+		"Red":     "#fd4b4b",
+		"Yellow":  "#fdcc4b",
+		"Blue":    "#4b4bfd",
+		"Magenta": "#fd4bfd",
+		"Cyan:":   "#4bffd9",
+		"White":   "#ffffff",
+		"Black":   "#000000",
+		"Gray":    "#383838",
+
+		"DarkYellow":  "#fdcc4b",
+		"DarkBlue":    "#4b4bfd",
+		"DarkRed":     "#fd4b4b",
+		"DarkGreen":   "#71fd4b",
+		"DarkCyan":    "#74ade9",
+		"DarkMagenta": "#fd4bfd",
+		"DarkWhite":   "#ffffff",
+
+		"LightMagenta": "#f9d5ff",
+
+		"TxeoCalculatorGreen": "#43BF6D",
+		"GooglePlusRed":       "#c27a71",
+		"GoogleBlue":          "#78a4ea",
+		"AndroidGreen":        "#93d396",
+	}
+}
+
+// DefaultTheme returns a fresh copy of the library's default look.
+func DefaultTheme() *Theme {
+	t := NewThemeFromTokens(DefaultTokens())
+	t.Name = "default"
+	return t
+}
+
+// NewTheme builds every style of the library from the given palette, with
+// tokens derived from it by TokensFromPalette. Use NewThemeFromTokens to
+// derive the palette from semantic tokens instead.
+func NewTheme(p Palette) *Theme {
+	t := &Theme{
+		Tokens:   TokensFromPalette(p),
+		Palette:  p,
+		renderer: DefaultRenderer(),
+		ActiveTabBorder: lipgloss.Border{
+			Top:         "─",
+			Bottom:      " ",
+			Left:        "│",
+			Right:       "│",
+			TopLeft:     "╭",
+			TopRight:    "╮",
+			BottomLeft:  "┘",
+			BottomRight: "└",
+		},
+		TabBorder: lipgloss.Border{
+			Top:         "─",
+			Bottom:      "─",
+			Left:        "│",
+			Right:       "│",
+			TopLeft:     "╭",
+			TopRight:    "╮",
+			BottomLeft:  "┴",
+			BottomRight: "┴",
+		},
+		PanelBorder: lipgloss.Border{
+			Top:         "─",
+			Bottom:      "─",
+			Left:        "│",
+			Right:       "│",
+			TopLeft:     "╭",
+			TopRight:    "╮",
+			BottomLeft:  "╰",
+			BottomRight: "╯",
+		},
+	}
+	t.build()
+	return t
+}
+
+// build (re)creates the styles from the palette and borders.
+func (t *Theme) build() {
+	p := t.Palette
+	r := t.renderer
+
+	// General.
+	t.DividerStyle = r.NewStyle().SetString("•").Padding(0, 1).Foreground(p.Subtle)
+	t.UrlStyle = r.NewStyle().Foreground(p.Special)
+	t.DocStyle = r.NewStyle().Padding(1, 1).Margin(0).Align(lipgloss.Center)
+
+	// Tabs.
+	t.RegularTab = r.NewStyle().Border(t.TabBorder, true).BorderForeground(p.Highlight).Padding(0, 1)
+	t.ActiveTab = t.RegularTab.Border(t.ActiveTabBorder, true).Bold(true)
+	t.TabGap = t.RegularTab.BorderTop(false).BorderLeft(false).BorderRight(false)
+
+	// Paragraphs/History.
+	t.PanelStyle = r.NewStyle().Border(t.PanelBorder).Align(lipgloss.Center).Foreground(p.PanelForeground).Margin(0, 1).Padding(1, 2)
+	t.ActiveStyle = r.NewStyle().BorderForeground(p.ActiveBorder).Align(lipgloss.Center).BorderStyle(lipgloss.DoubleBorder())
+	t.NonActiveStyle = r.NewStyle().BorderForeground(p.InactiveBorder).Align(lipgloss.Center).UnsetBorderStyle()
+
+	// Title.
+	t.TitleStyle = r.NewStyle().Align(lipgloss.Left).Foreground(p.Title).Bold(true).Margin(2, 0, 0, 0)
+	t.DescStyle = r.NewStyle().Align(lipgloss.Left).MarginTop(1).Foreground(p.Description).Inline(true)
+	t.InfoStyle = r.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderTop(true).BorderForeground(p.Subtle)
+
+	// Dialog.
+	t.DialogBoxStyle = r.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(p.DialogBorder).Padding(1, 0).BorderTop(true).BorderLeft(true).BorderRight(true).BorderBottom(true)
+	t.ButtonStyle = r.NewStyle().Foreground(p.ButtonForeground).Background(p.ButtonBackground).Padding(0, 3).MarginTop(1)
+	t.ActiveButtonStyle = t.ButtonStyle.Foreground(p.ButtonForeground).Background(p.ActiveButtonBackground).MarginRight(2).Underline(true)
+	t.WidthInfoStyle = r.NewStyle().Background(p.WidthInfoBackground).Bold(true).Foreground(p.WidthInfoForeground)
+	t.DialogStyle = r.NewStyle().Width(50).Align(lipgloss.Center)
+
+	// List.
+	t.ListExample = r.NewStyle().Border(lipgloss.NormalBorder(), false, true, false, false).BorderForeground(p.Subtle).MarginRight(2).Height(8)
+	t.ListHeaderStyle = r.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderBottom(true).BorderForeground(p.Subtle).MarginRight(2)
+	t.ListItemStyle = r.NewStyle().PaddingLeft(2)
+	t.CheckMarkStyle = r.NewStyle().SetString("✓").Foreground(p.Special).PaddingRight(1)
+	t.ListDoneStyle = r.NewStyle().Strikethrough(true).Foreground(p.Done)
+
+	// Status Bar.
+	t.StatusNugget = r.NewStyle().Foreground(p.StatusNugget).Padding(0, 1)
+	t.StatusBarStyle = r.NewStyle().Foreground(p.StatusBarForeground).Background(p.StatusBarBackground)
+	t.StatusStyle = r.NewStyle().Inherit(t.StatusBarStyle).Foreground(p.StatusNugget).Background(p.StatusAccent).Bold(true).Padding(0, 1).MarginRight(1)
+	t.StatusText = r.NewStyle().Inherit(t.StatusBarStyle)
+	t.StatusStrong = t.StatusNugget.Foreground(p.StatusBoardForeground).Background(p.StatusBoardBackground).Align(lipgloss.Right).Bold(true)
+	t.StatusInfo = t.StatusNugget.Background(p.StatusList)
+	t.StatusPrimary = t.StatusNugget.Background(p.StatusUsername)
+	t.ViewportTitleStyle = func() lipgloss.Style {
+		b := lipgloss.RoundedBorder()
+		b.Right = "├"
+		return r.NewStyle().BorderStyle(b).Padding(0, 1)
+	}()
+
+	// Hours Distribution.
+	t.HoursDistributionStyle = r.NewStyle().
+		Foreground(p.HoursForeground).
+		Background(p.HoursBackground).
+		Margin(0, 1).
+		Padding(0, 5).Align(lipgloss.Center)
+	t.CalendarHoursDistributionStyle = r.NewStyle().
+		Foreground(p.HoursForeground).
+		Background(p.HoursBackground)
+
+	// Main content, inputs and bordered boxes.
+	t.BrandStyle = r.NewStyle().
+		Foreground(p.BrandForeground).
+		Background(p.BrandBackground).
+		Padding(1, 2).
+		Margin(1).
+		Align(lipgloss.Center)
+	t.UserInputStyle = r.NewStyle().Foreground(p.UserInput)
+	t.SuggestionStyle = r.NewStyle().Foreground(p.Suggestion)
+	t.BaseBorderedStyle = r.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(p.InactiveBorder).Align(lipgloss.Left).Padding(1, 4)
+	t.CompactBorderedStyle = r.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(p.CompactBorder).Align(lipgloss.Left).Padding(0, 4)
+	t.BaseStyle = r.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(p.InactiveBorder)
+	t.NoBorderedStyle = r.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderTop(false).BorderLeft(false).BorderRight(false).BorderBottom(false)
+
+	// Bubble table.
+	t.StyleSubtle = r.NewStyle().Foreground(p.TableSubtle)
+	t.StyleBase = r.NewStyle().
+		Foreground(p.TableForeground).
+		BorderForeground(p.TableBorder).
+		Align(lipgloss.Left).Padding(0, 1)
+	t.StyleBaseRow = t.StyleBase
+	t.StyleCentered = r.NewStyle().Align(lipgloss.Center).Padding(0, 1)
+	t.StyleLeft = r.NewStyle().Align(lipgloss.Left).Margin(0, 1)
+	t.StyleRight = r.NewStyle().Align(lipgloss.Right).Padding(0, 1)
+
+	// Bubble views.
+	t.KeywordStyle = r.NewStyle().Foreground(p.Keyword)
+	t.SubtleStyle = r.NewStyle().Foreground(p.SubtleText)
+	t.TicksStyle = r.NewStyle().Foreground(p.Ticks)
+	t.CheckboxStyle = r.NewStyle().Foreground(p.Checkbox)
+	t.DotStyle = r.NewStyle().Foreground(p.Dot)
+	t.MainStyle = r.NewStyle().MarginLeft(2)
+	t.HelpStyle = r.NewStyle().Foreground(p.SubtleText)
+	t.Ramp = makeRampStyles(p.RampStart, p.RampEnd, ProgressBarWidth)
+
+	// Messages.
+	t.ErrorStyle = r.NewStyle().Foreground(p.ErrorColor)
+	t.WarningStyle = r.NewStyle().Foreground(t.Tokens.Warning)
+	t.SuccessStyle = r.NewStyle().Foreground(p.Special)
+	t.NoticeStyle = r.NewStyle().Foreground(p.StatusAccent)
+	t.ToastStyle = r.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+
+	t.syncDeprecated()
+}
+
+// syncDeprecated copies the styles kept under their former names.
+func (t *Theme) syncDeprecated() {
+	t.CurrentBoardStyle = t.StatusStrong
+	t.CurrentListStyle = t.StatusInfo
+	t.CurrentUsernameStyle = t.StatusPrimary
+}
+
+// ColorProfile returns the profile the theme's styles render with.
+func (t *Theme) ColorProfile() termenv.Profile {
+	return t.renderer.ColorProfile()
+}
+
+// Renderer returns the renderer the theme's styles are bound to.
+func (t *Theme) Renderer() *Renderer {
+	return t.renderer
+}
+
+// WithRenderer returns a copy of t whose styles render for r.
+func (t *Theme) WithRenderer(r *Renderer) *Theme {
+	c := *t
+	c.renderer = r
+	c.useRenderer()
+	return &c
+}
+
+// WithColorProfile returns a copy of t rendering with profile p, whatever the
+// terminal or the package-level profile say. Use it for output that ends up
+// somewhere else than the local terminal, like CI logs.
+func (t *Theme) WithColorProfile(p termenv.Profile) *Theme {
+	r := NewRenderer(t.renderer.Writer())
+	r.SetColorProfile(p)
+	r.SetHasDarkBackground(t.renderer.HasDarkBackground())
+	return t.WithRenderer(r)
+}
+
+// useRenderer moves every style over to t's renderer, keeping any change
+// made to them since build.
+func (t *Theme) useRenderer() {
+	for _, s := range themeStyles(t) {
+		*s = s.Renderer(t.renderer.lg)
+	}
+	t.syncDeprecated()
+	ramp := make([]lipgloss.Style, len(t.Ramp))
+	for i, s := range t.Ramp {
+		ramp[i] = s.Renderer(t.renderer.lg)
+	}
+	t.Ramp = ramp
+}
+
+// Divider renders the dot used to separate inline items.
+func (t *Theme) Divider() string {
+	return t.DividerStyle.String()
+}
+
+// CheckMark renders the "✓" prefix used by done list items.
+func (t *Theme) CheckMark() string {
+	return t.CheckMarkStyle.String()
+}
+
+// ListDone renders a crossed-out list item preceded by a check mark.
+func (t *Theme) ListDone(s string) string {
+	return t.CheckMark() + t.ListDoneStyle.Render(s)
+}
+
+// StringInColor renders s using one of the palette's named HEX colors.
+func (t *Theme) StringInColor(color string, s string) string {
+	return t.renderer.NewStyle().Foreground(lipgloss.Color(t.Palette.HEX[color])).Render(s)
+}
@@ -0,0 +1,976 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │               THEME FILES                │ */
+/* ╰──────────────────────────────────────────╯ */
+//
+// A theme file has the same shape in every format. Every section and key is
+// optional; anything left out keeps the value of DefaultTheme.
+//
+//	name = "midnight"
+//
+//	[tokens]                 # semantic colors every slot below derives from
+//	primary = "#7D56F4"
+//
+//	[colors]
+//	highlight = { light = "#874BFD", dark = "#7D56F4" }
+//	subtle = "#383838"       # same color on light and dark backgrounds
+//	special = "Green"        # a name from the [hex] table
+//
+//	[hex]
+//	Green = "#71fd4b"
+//
+//	[borders.panel]
+//	top_left = "┌"
+//
+//	[styles.panel]
+//	padding = [1, 2]
+//	margin = [0, 1]
+
+// ThemeFormat is the encoding of a theme file.
+type ThemeFormat string
+
+const (
+	ThemeTOML ThemeFormat = "toml"
+	ThemeJSON ThemeFormat = "json"
+	ThemeYAML ThemeFormat = "yaml"
+)
+
+// ThemeFormatFromPath guesses the format of a theme file from its extension.
+func ThemeFormatFromPath(path string) (ThemeFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return ThemeTOML, nil
+	case ".json":
+		return ThemeJSON, nil
+	case ".yaml", ".yml":
+		return ThemeYAML, nil
+	}
+	return "", fmt.Errorf("unknown theme format for %q (want .toml, .json, .yaml or .yml)", path)
+}
+
+// ThemeError reports a problem found while reading a theme file.
+type ThemeError struct {
+	File string // Path of the file, empty when parsing raw data.
+	Line int    // Line number, starting at 1. Zero when unknown.
+	Key  string // Dotted key, e.g. "colors.highlight". Empty for syntax errors.
+	Msg  string
+}
+
+func (e *ThemeError) Error() string {
+	var sb strings.Builder
+	switch {
+	case e.File != "" && e.Line > 0:
+		fmt.Fprintf(&sb, "%s:%d: ", e.File, e.Line)
+	case e.File != "":
+		fmt.Fprintf(&sb, "%s: ", e.File)
+	case e.Line > 0:
+		fmt.Fprintf(&sb, "line %d: ", e.Line)
+	}
+	if e.Key != "" {
+		sb.WriteString(e.Key + ": ")
+	}
+	sb.WriteString(e.Msg)
+	return sb.String()
+}
+
+// LoadTheme reads a theme file, picking the format from its extension.
+// Validation errors are *ThemeError values (joined when there are several).
+func LoadTheme(path string) (*Theme, error) {
+	format, err := ThemeFormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTheme(data, format, path)
+	if err != nil {
+		return nil, err
+	}
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return t, nil
+}
+
+// ParseTheme builds a theme from the contents of a theme file.
+func ParseTheme(data []byte, format ThemeFormat) (*Theme, error) {
+	return parseTheme(data, format, "")
+}
+
+// SaveTheme writes t to path, picking the format from its extension.
+func SaveTheme(t *Theme, path string) error {
+	format, err := ThemeFormatFromPath(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := EncodeTheme(&buf, t, format); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// EncodeTheme dumps t in the given format. Only non-zero spacing is written.
+func EncodeTheme(w io.Writer, t *Theme, format ThemeFormat) error {
+	doc := themeDocument(t)
+	switch format {
+	case ThemeTOML:
+		return toml.NewEncoder(w).Encode(doc)
+	case ThemeJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case ThemeYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	return fmt.Errorf("unknown theme format %q", format)
+}
+
+/* ╭──────────────────────────────────────────╮ */
+/* │              THEME SLOTS                 │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// themeKey turns a Go field name into the snake_case key used in theme files.
+func themeKey(field string) string {
+	var sb strings.Builder
+	runes := []rune(field)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+var (
+	adaptiveColorType = reflect.TypeOf(lipgloss.AdaptiveColor{})
+	borderType        = reflect.TypeOf(lipgloss.Border{})
+	styleType         = reflect.TypeOf(lipgloss.Style{})
+)
+
+// colorFields maps color keys to the fields holding them in a *Palette or
+// *Tokens.
+func colorFields(ptr any) map[string]reflect.Value {
+	slots := map[string]reflect.Value{}
+	v := reflect.ValueOf(ptr).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.Type == adaptiveColorType || f.Type.Kind() == reflect.String {
+			slots[themeKey(f.Name)] = v.Field(i)
+		}
+	}
+	return slots
+}
+
+// themeBorders maps border keys ("panel", "tab"...) to the theme's borders.
+func themeBorders(t *Theme) map[string]*lipgloss.Border {
+	slots := map[string]*lipgloss.Border{}
+	v := reflect.ValueOf(t).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.Type == borderType {
+			slots[strings.TrimSuffix(themeKey(f.Name), "_border")] = v.Field(i).Addr().Interface().(*lipgloss.Border)
+		}
+	}
+	return slots
+}
+
+// renamedStyles maps the keys of deprecated style fields to the keys of the
+// fields replacing them, so older theme files still load.
+var renamedStyles = map[string]string{
+	"current_board":    "status_strong",
+	"current_list":     "status_info",
+	"current_username": "status_primary",
+}
+
+// themeStyles maps style keys ("panel", "dialog_box"...) to the theme's styles,
+// leaving out the deprecated ones.
+func themeStyles(t *Theme) map[string]*lipgloss.Style {
+	slots := map[string]*lipgloss.Style{}
+	v := reflect.ValueOf(t).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		key := strings.TrimSuffix(themeKey(f.Name), "_style")
+		if _, renamed := renamedStyles[key]; f.Type == styleType && !renamed {
+			slots[key] = v.Field(i).Addr().Interface().(*lipgloss.Style)
+		}
+	}
+	return slots
+}
+
+// borderSides maps side keys ("top", "bottom_left"...) to the border's fields.
+func borderSides(b *lipgloss.Border) map[string]*string {
+	sides := map[string]*string{}
+	v := reflect.ValueOf(b).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		sides[themeKey(v.Type().Field(i).Name)] = v.Field(i).Addr().Interface().(*string)
+	}
+	return sides
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/* ╭──────────────────────────────────────────╮ */
+/* │                 DECODING                 │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// themeNode is a format independent view of a theme file that remembers the
+// line every value was defined on.
+type themeNode struct {
+	line  int
+	value any // string, int64, float64 or bool for scalars.
+	items map[string]*themeNode
+	keys  []string // Keys of items in file order.
+	list  []*themeNode
+}
+
+func (n *themeNode) isMap() bool  { return n.items != nil }
+func (n *themeNode) isList() bool { return n.list != nil }
+
+func (n *themeNode) set(key string, child *themeNode) {
+	if n.items == nil {
+		n.items = map[string]*themeNode{}
+	}
+	if _, ok := n.items[key]; !ok {
+		n.keys = append(n.keys, key)
+	}
+	n.items[key] = child
+}
+
+func (n *themeNode) describe() string {
+	switch {
+	case n.isMap():
+		return "a table"
+	case n.isList():
+		return "a list"
+	}
+	switch n.value.(type) {
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	}
+	return "a number"
+}
+
+func (n *themeNode) int() (int, bool) {
+	switch v := n.value.(type) {
+	case int64:
+		return int(v), true
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+func parseTheme(data []byte, format ThemeFormat, file string) (*Theme, error) {
+	var (
+		root *themeNode
+		err  error
+	)
+	switch format {
+	case ThemeTOML:
+		root, err = parseTOMLNode(data)
+	case ThemeJSON:
+		root, err = parseJSONNode(data)
+	case ThemeYAML:
+		root, err = parseYAMLNode(data)
+	default:
+		return nil, fmt.Errorf("unknown theme format %q", format)
+	}
+	if err != nil {
+		var te *ThemeError
+		if errors.As(err, &te) {
+			te.File = file
+		}
+		return nil, err
+	}
+	d := themeDecoder{file: file}
+	t := d.decode(root)
+	if len(d.errs) > 0 {
+		return nil, errors.Join(d.errs...)
+	}
+	return t, nil
+}
+
+// themeDecoder collects every validation error instead of stopping at the first.
+type themeDecoder struct {
+	file string
+	errs []error
+}
+
+func (d *themeDecoder) fail(n *themeNode, key, format string, args ...any) {
+	d.errs = append(d.errs, &ThemeError{File: d.file, Line: n.line, Key: key, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (d *themeDecoder) table(n *themeNode, key string) bool {
+	if !n.isMap() {
+		d.fail(n, key, "expected a table, got %s", n.describe())
+		return false
+	}
+	return true
+}
+
+func (d *themeDecoder) decode(root *themeNode) *Theme {
+	t := DefaultTheme()
+	t.Name = ""
+	if root == nil || (!root.isMap() && !root.isList() && root.value == nil) {
+		return t
+	}
+	if !d.table(root, "") {
+		return t
+	}
+
+	// Named colors first, so the color slots can refer to them.
+	if hex, ok := root.items["hex"]; ok && d.table(hex, "hex") {
+		for _, name := range hex.keys {
+			c := hex.items[name]
+			key := "hex." + name
+			s, ok := c.value.(string)
+			if !ok || !hexColorRE.MatchString(s) {
+				d.fail(c, key, "expected a \"#RRGGBB\" color, got %s", c.describe())
+				continue
+			}
+			t.Palette.HEX[name] = s
+		}
+	}
+
+	// Tokens next, as every palette slot derives from them.
+	if n, ok := root.items["tokens"]; ok && d.table(n, "tokens") {
+		d.decodeColors(n, "tokens", colorFields(&t.Tokens), t.Palette.HEX, true)
+		hex := t.Palette.HEX
+		t.Palette = t.Tokens.Palette()
+		t.Palette.HEX = hex
+	}
+
+	for _, section := range root.keys {
+		n := root.items[section]
+		switch section {
+		case "name":
+			if s, ok := n.value.(string); ok {
+				t.Name = s
+			} else {
+				d.fail(n, section, "expected a string, got %s", n.describe())
+			}
+		case "hex", "tokens":
+		case "colors":
+			if d.table(n, section) {
+				d.decodeColors(n, section, colorFields(&t.Palette), t.Palette.HEX, false)
+			}
+		case "borders", "styles":
+		default:
+			d.fail(n, section, "unknown section (want name, tokens, colors, hex, borders or styles)")
+		}
+	}
+
+	// Borders are part of the style definitions, so rebuild before spacing.
+	if n, ok := root.items["borders"]; ok && d.table(n, "borders") {
+		d.decodeBorders(n, t)
+	}
+	t.build()
+	if n, ok := root.items["styles"]; ok && d.table(n, "styles") {
+		d.decodeStyles(n, t)
+	}
+	return t
+}
+
+var (
+	hexColorRE  = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	ansiColorRE = regexp.MustCompile(`^[0-9]{1,3}$`)
+)
+
+// color resolves a color value: a "#hex" string, an ANSI 0-255 code or the
+// name of an entry in the HEX map.
+func (d *themeDecoder) color(n *themeNode, key string, hex map[string]string) (string, bool) {
+	if i, ok := n.int(); ok {
+		if i < 0 || i > 255 {
+			d.fail(n, key, "ANSI color %d out of range 0-255", i)
+			return "", false
+		}
+		return strconv.Itoa(i), true
+	}
+	s, ok := n.value.(string)
+	if !ok {
+		d.fail(n, key, "expected a color, got %s", n.describe())
+		return "", false
+	}
+	switch {
+	case hexColorRE.MatchString(s):
+		return s, true
+	case ansiColorRE.MatchString(s):
+		if i, _ := strconv.Atoi(s); i > 255 {
+			d.fail(n, key, "ANSI color %d out of range 0-255", i)
+			return "", false
+		}
+		return s, true
+	}
+	if c, ok := hex[s]; ok {
+		return c, true
+	}
+	d.fail(n, key, "invalid color %q (want \"#RRGGBB\", an ANSI code 0-255 or a name from [hex])", s)
+	return "", false
+}
+
+// decodeColors fills the color fields in slots. hexOnly rejects ANSI codes,
+// which gradients and animations cannot blend.
+func (d *themeDecoder) decodeColors(n *themeNode, section string, slots map[string]reflect.Value, hex map[string]string, hexOnly bool) {
+	color := func(n *themeNode, key string, hexOnly bool) (string, bool) {
+		s, ok := d.color(n, key, hex)
+		if ok && hexOnly && !hexColorRE.MatchString(s) {
+			d.fail(n, key, "must be a \"#RRGGBB\" color, got %q", s)
+			return "", false
+		}
+		return s, ok
+	}
+	for _, name := range n.keys {
+		c := n.items[name]
+		key := section + "." + name
+		slot, ok := slots[name]
+		if !ok {
+			d.fail(c, key, "unknown %s (want one of %s)", strings.TrimSuffix(section, "s"), strings.Join(sortedKeys(slots), ", "))
+			continue
+		}
+		if slot.Type() != adaptiveColorType {
+			if s, ok := color(c, key, true); ok {
+				slot.SetString(s)
+			}
+			continue
+		}
+		if !c.isMap() {
+			if s, ok := color(c, key, hexOnly); ok {
+				slot.Set(reflect.ValueOf(solid(s)))
+			}
+			continue
+		}
+		ac := slot.Interface().(lipgloss.AdaptiveColor)
+		for _, side := range c.keys {
+			v := c.items[side]
+			switch side {
+			case "light":
+				if s, ok := color(v, key+".light", hexOnly); ok {
+					ac.Light = s
+				}
+			case "dark":
+				if s, ok := color(v, key+".dark", hexOnly); ok {
+					ac.Dark = s
+				}
+			default:
+				d.fail(v, key+"."+side, "unknown key (want light or dark)")
+			}
+		}
+		slot.Set(reflect.ValueOf(ac))
+	}
+}
+
+func (d *themeDecoder) decodeBorders(n *themeNode, t *Theme) {
+	slots := themeBorders(t)
+	for _, name := range n.keys {
+		b := n.items[name]
+		key := "borders." + name
+		border, ok := slots[name]
+		if !ok {
+			d.fail(b, key, "unknown border (want one of %s)", strings.Join(sortedKeys(slots), ", "))
+			continue
+		}
+		if !d.table(b, key) {
+			continue
+		}
+		sides := borderSides(border)
+		for _, side := range b.keys {
+			v := b.items[side]
+			ptr, ok := sides[side]
+			if !ok {
+				d.fail(v, key+"."+side, "unknown border side (want one of %s)", strings.Join(sortedKeys(sides), ", "))
+				continue
+			}
+			s, ok := v.value.(string)
+			if !ok {
+				d.fail(v, key+"."+side, "expected a string, got %s", v.describe())
+				continue
+			}
+			if lipgloss.Width(s) != 1 {
+				d.fail(v, key+"."+side, "expected a single character, got %q", s)
+				continue
+			}
+			*ptr = s
+		}
+	}
+}
+
+func (d *themeDecoder) decodeStyles(n *themeNode, t *Theme) {
+	slots := themeStyles(t)
+	for _, name := range n.keys {
+		s := n.items[name]
+		key := "styles." + name
+		style, ok := slots[name]
+		if renamed, found := renamedStyles[name]; found {
+			style, ok = slots[renamed]
+		}
+		if !ok {
+			d.fail(s, key, "unknown style (want one of %s)", strings.Join(sortedKeys(slots), ", "))
+			continue
+		}
+		if !d.table(s, key) {
+			continue
+		}
+		for _, prop := range s.keys {
+			v := s.items[prop]
+			switch prop {
+			case "padding":
+				if sides, ok := d.spacing(v, key+"."+prop); ok {
+					*style = style.Padding(sides...)
+				}
+			case "margin":
+				if sides, ok := d.spacing(v, key+"."+prop); ok {
+					*style = style.Margin(sides...)
+				}
+			default:
+				d.fail(v, key+"."+prop, "unknown property (want padding or margin)")
+			}
+		}
+	}
+	t.syncDeprecated()
+}
+
+// spacing reads CSS-like shorthand: a number or a list of 1 to 4 numbers.
+func (d *themeDecoder) spacing(n *themeNode, key string) ([]int, bool) {
+	items := n.list
+	if !n.isList() {
+		items = []*themeNode{n}
+	}
+	if len(items) < 1 || len(items) > 4 {
+		d.fail(n, key, "expected 1 to 4 values, got %d", len(items))
+		return nil, false
+	}
+	sides := make([]int, len(items))
+	for i, item := range items {
+		v, ok := item.int()
+		if !ok {
+			d.fail(item, key, "expected a whole number, got %s", item.describe())
+			return nil, false
+		}
+		if v < 0 {
+			d.fail(item, key, "must not be negative, got %d", v)
+			return nil, false
+		}
+		sides[i] = v
+	}
+	return sides, true
+}
+
+/* ╭──────────────────────────────────────────╮ */
+/* │                 ENCODING                 │ */
+/* ╰──────────────────────────────────────────╯ */
+
+func encodeColor(c lipgloss.AdaptiveColor) any {
+	if c.Light == c.Dark {
+		return c.Light
+	}
+	return map[string]any{"light": c.Light, "dark": c.Dark}
+}
+
+// themeDocument turns t into the generic shape shared by all file formats.
+func themeDocument(t *Theme) map[string]any {
+	colors := map[string]any{}
+	for key, v := range colorFields(&t.Palette) {
+		if c, ok := v.Interface().(lipgloss.AdaptiveColor); ok {
+			colors[key] = encodeColor(c)
+		} else {
+			colors[key] = v.String()
+		}
+	}
+
+	tokens := map[string]any{}
+	for key, v := range colorFields(&t.Tokens) {
+		tokens[key] = encodeColor(v.Interface().(lipgloss.AdaptiveColor))
+	}
+
+	hex := map[string]any{}
+	for name, c := range t.Palette.HEX {
+		hex[name] = c
+	}
+
+	borders := map[string]any{}
+	for key, b := range themeBorders(t) {
+		sides := map[string]any{}
+		for side, s := range borderSides(b) {
+			if *s != "" {
+				sides[side] = *s
+			}
+		}
+		borders[key] = sides
+	}
+
+	styles := map[string]any{}
+	for key, s := range themeStyles(t) {
+		props := map[string]any{}
+		if top, right, bottom, left := s.GetPadding(); top+right+bottom+left > 0 {
+			props["padding"] = []int{top, right, bottom, left}
+		}
+		if top, right, bottom, left := s.GetMargin(); top+right+bottom+left > 0 {
+			props["margin"] = []int{top, right, bottom, left}
+		}
+		if len(props) > 0 {
+			styles[key] = props
+		}
+	}
+
+	return map[string]any{
+		"name":    t.Name,
+		"tokens":  tokens,
+		"colors":  colors,
+		"hex":     hex,
+		"borders": borders,
+		"styles":  styles,
+	}
+}
+
+/* ╭──────────────────────────────────────────╮ */
+/* │                 PARSERS                  │ */
+/* ╰──────────────────────────────────────────╯ */
+
+func parseYAMLNode(data []byte) (*themeNode, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		line := 0
+		if m := yamlLineRE.FindStringSubmatch(msg); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			msg = strings.TrimPrefix(msg, m[0])
+		}
+		return nil, &ThemeError{Line: line, Msg: msg}
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return yamlToNode(doc.Content[0])
+}
+
+var yamlLineRE = regexp.MustCompile(`^line (\d+): `)
+
+func yamlToNode(y *yaml.Node) (*themeNode, error) {
+	n := &themeNode{line: y.Line}
+	switch y.Kind {
+	case yaml.AliasNode:
+		return yamlToNode(y.Alias)
+	case yaml.MappingNode:
+		n.items = map[string]*themeNode{}
+		for i := 0; i+1 < len(y.Content); i += 2 {
+			child, err := yamlToNode(y.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			child.line = y.Content[i].Line
+			n.set(y.Content[i].Value, child)
+		}
+	case yaml.SequenceNode:
+		n.list = []*themeNode{}
+		for _, item := range y.Content {
+			child, err := yamlToNode(item)
+			if err != nil {
+				return nil, err
+			}
+			n.list = append(n.list, child)
+		}
+	case yaml.ScalarNode:
+		switch y.Tag {
+		case "!!int":
+			i, err := strconv.ParseInt(y.Value, 0, 64)
+			if err != nil {
+				return nil, &ThemeError{Line: y.Line, Msg: err.Error()}
+			}
+			n.value = i
+		case "!!float":
+			f, err := strconv.ParseFloat(y.Value, 64)
+			if err != nil {
+				return nil, &ThemeError{Line: y.Line, Msg: err.Error()}
+			}
+			n.value = f
+		case "!!bool":
+			n.value = y.Value == "true"
+		case "!!null":
+		default:
+			n.value = y.Value
+		}
+	}
+	return n, nil
+}
+
+func parseJSONNode(data []byte) (*themeNode, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n, err := jsonToNode(dec, data)
+	if err != nil {
+		var se *json.SyntaxError
+		if errors.As(err, &se) {
+			return nil, &ThemeError{Line: lineAt(data, se.Offset), Msg: se.Error()}
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return nil, &ThemeError{Line: lineAt(data, int64(len(data))), Msg: "unexpected end of JSON input"}
+		}
+		return nil, err
+	}
+	return n, nil
+}
+
+func jsonToNode(dec *json.Decoder, data []byte) (*themeNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	n := &themeNode{line: lineAt(data, dec.InputOffset())}
+	switch v := tok.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			n.items = map[string]*themeNode{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				line := lineAt(data, dec.InputOffset())
+				child, err := jsonToNode(dec, data)
+				if err != nil {
+					return nil, err
+				}
+				child.line = line
+				n.set(key.(string), child)
+			}
+		case '[':
+			n.list = []*themeNode{}
+			for dec.More() {
+				child, err := jsonToNode(dec, data)
+				if err != nil {
+					return nil, err
+				}
+				n.list = append(n.list, child)
+			}
+		}
+		// Closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			n.value = i
+		} else {
+			f, _ := v.Float64()
+			n.value = f
+		}
+	case string, bool:
+		n.value = v
+	}
+	return n, nil
+}
+
+// lineAt returns the 1-based line holding the byte at offset.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+func parseTOMLNode(data []byte) (*themeNode, error) {
+	var doc map[string]any
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		var pe toml.ParseError
+		if errors.As(err, &pe) {
+			return nil, &ThemeError{Line: pe.Position.Line, Msg: pe.Message}
+		}
+		return nil, err
+	}
+	return tomlToNode(doc, "", tomlKeyLines(data)), nil
+}
+
+func tomlToNode(v any, path string, lines map[string]int) *themeNode {
+	n := &themeNode{line: lines[path]}
+	switch v := v.(type) {
+	case map[string]any:
+		n.items = map[string]*themeNode{}
+		keys := sortedKeys(v)
+		// Keep file order so errors come out top to bottom.
+		sort.SliceStable(keys, func(i, j int) bool {
+			return lines[joinKey(path, keys[i])] < lines[joinKey(path, keys[j])]
+		})
+		for _, k := range keys {
+			n.set(k, tomlToNode(v[k], joinKey(path, k), lines))
+		}
+	case []any:
+		n.list = []*themeNode{}
+		for _, item := range v {
+			child := tomlToNode(item, path, lines)
+			if child.line == 0 {
+				child.line = n.line
+			}
+			n.list = append(n.list, child)
+		}
+	case []map[string]any:
+		n.list = []*themeNode{}
+		for _, item := range v {
+			n.list = append(n.list, tomlToNode(item, path, lines))
+		}
+	default:
+		n.value = v
+	}
+	return n
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// tomlKeyLines finds the line every key of a TOML document is defined on.
+// The BurntSushi decoder keeps key positions to itself, so this does a light
+// pass over the source that understands tables, dotted keys and inline tables.
+func tomlKeyLines(data []byte) map[string]int {
+	lines := map[string]int{}
+	table := ""
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(stripTOMLComment(raw))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			name := strings.Trim(line, "[] ")
+			table = strings.Join(splitTOMLKey(name), ".")
+			for p := table; p != ""; p = parentKey(p) {
+				if _, ok := lines[p]; !ok {
+					lines[p] = i + 1
+				}
+			}
+			continue
+		}
+		tomlInlineKeys(line, table, i+1, lines)
+	}
+	return lines
+}
+
+// tomlInlineKeys records "key = value" pairs found in s, descending into
+// inline tables.
+func tomlInlineKeys(s, table string, line int, lines map[string]int) {
+	for s != "" {
+		eq := indexOutsideQuotes(s, '=')
+		if eq < 0 {
+			return
+		}
+		path := joinKey(table, strings.Join(splitTOMLKey(strings.TrimSpace(s[:eq])), "."))
+		lines[path] = line
+		rest := strings.TrimSpace(s[eq+1:])
+		if !strings.HasPrefix(rest, "{") {
+			return
+		}
+		end := strings.LastIndex(rest, "}")
+		if end < 0 {
+			end = len(rest)
+		}
+		for _, pair := range splitOutsideQuotes(rest[1:end], ',') {
+			tomlInlineKeys(strings.TrimSpace(pair), path, line, lines)
+		}
+		return
+	}
+}
+
+func parentKey(path string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+func splitTOMLKey(key string) []string {
+	parts := splitOutsideQuotes(key, '.')
+	for i, p := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(p), `"'`)
+	}
+	return parts
+}
+
+func stripTOMLComment(s string) string {
+	if i := indexOutsideQuotes(s, '#'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func indexOutsideQuotes(s string, sep byte) int {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		case c == sep && depth <= 0:
+			return i
+		}
+	}
+	return -1
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	for {
+		i := indexOutsideQuotes(s, sep)
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+1:]
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// themeErrors returns the *ThemeError values in err, joined or not.
+func themeErrors(err error) []*ThemeError {
+	var errs []error
+	if j, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = j.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	var tes []*ThemeError
+	for _, e := range errs {
+		var te *ThemeError
+		if errors.As(e, &te) {
+			tes = append(tes, te)
+		}
+	}
+	return tes
+}
+
+func TestParseThemeErrors(t *testing.T) {
+	type want struct {
+		line int
+		key  string
+	}
+	tests := []struct {
+		name   string
+		format ThemeFormat
+		data   string
+		want   []want
+	}{
+		{"toml bad color", ThemeTOML, "name = \"x\"\n\n[colors]\nhighlight = \"#zzz\"\n", []want{{4, "colors.highlight"}}},
+		{"toml syntax", ThemeTOML, "name = \"x\"\n[colors\n", []want{{3, ""}}},
+		{"toml unknown keys", ThemeTOML, "[colors]\nnope = \"#fff\"\n[bogus]\n", []want{{2, "colors.nope"}, {3, "bogus"}}},
+		{"toml padding", ThemeTOML, "[styles.panel]\npadding = [1, 2, 3, 4, 5]\n", []want{{2, "styles.panel.padding"}}},
+		{"toml ansi range", ThemeTOML, "[tokens]\nprimary = 300\n", []want{{2, "tokens.primary"}}},
+		{"yaml bad color", ThemeYAML, "name: x\ncolors:\n  highlight: \"#zzz\"\n", []want{{3, "colors.highlight"}}},
+		{"yaml syntax", ThemeYAML, "name: x\ncolors:\n  - a\n b: c\n", []want{{3, ""}}},
+		{"json bad color", ThemeJSON, "{\n  \"name\": \"x\",\n  \"colors\": {\n    \"highlight\": \"#zzz\"\n  }\n}\n", []want{{4, "colors.highlight"}}},
+		{"json syntax", ThemeJSON, "{\n  \"name\": \"x\",\n  \"colors\": {,}\n}", []want{{3, ""}}},
+		{"json truncated", ThemeJSON, "{\n  \"name\": \"x\",\n  \"colors\": {\n", []want{{4, ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTheme([]byte(tt.data), tt.format)
+			if err == nil {
+				t.Fatal("ParseTheme succeeded, want an error")
+			}
+			got := themeErrors(err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d errors (%v), want %d", len(got), err, len(tt.want))
+			}
+			for i, w := range tt.want {
+				if got[i].Line != w.line || got[i].Key != w.key {
+					t.Errorf("error %d at line %d key %q, want line %d key %q (%v)", i, got[i].Line, got[i].Key, w.line, w.key, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadThemeErrorNamesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.toml")
+	if err := os.WriteFile(path, []byte("[colors]\nhighlight = \"#zzz\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadTheme(path)
+	if err == nil || !strings.HasPrefix(err.Error(), path+":2: colors.highlight: ") {
+		t.Errorf("LoadTheme error = %v, want it to start with %q", err, path+":2: colors.highlight: ")
+	}
+}
+
+func TestThemeFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want ThemeFormat
+		ok   bool
+	}{
+		{"theme.toml", ThemeTOML, true},
+		{"theme.JSON", ThemeJSON, true},
+		{"theme.yaml", ThemeYAML, true},
+		{"theme.yml", ThemeYAML, true},
+		{"theme.ini", "", false},
+		{"theme", "", false},
+	}
+	for _, tt := range tests {
+		got, err := ThemeFormatFromPath(tt.path)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("ThemeFormatFromPath(%q) = %q, %v", tt.path, got, err)
+		}
+	}
+}
+
+func TestThemeRoundTrip(t *testing.T) {
+	for _, format := range []ThemeFormat{ThemeTOML, ThemeYAML, ThemeJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var sb strings.Builder
+			if err := EncodeTheme(&sb, DefaultTheme(), format); err != nil {
+				t.Fatal(err)
+			}
+			th, err := ParseTheme([]byte(sb.String()), format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if th.Palette.Highlight != DefaultPalette().Highlight || th.Palette.Keyword != DefaultPalette().Keyword {
+				t.Errorf("round trip changed the palette: %+v", th.Palette)
+			}
+		})
+	}
+}
+
+func TestThemeRenamedStyleKeys(t *testing.T) {
+	th, err := ParseTheme([]byte(`{"styles": {"current_board": {"padding": 2}}}`), ThemeJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if top, _, _, _ := th.StatusStrong.GetPadding(); top != 2 {
+		t.Errorf("styles.current_board padding top = %d on StatusStrong, want 2", top)
+	}
+	if top, _, _, _ := th.CurrentBoardStyle.GetPadding(); top != 2 {
+		t.Errorf("deprecated CurrentBoardStyle padding top = %d, want 2", top)
+	}
+	var sb strings.Builder
+	if err := EncodeTheme(&sb, th, ThemeJSON); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sb.String(), "current_board") {
+		t.Errorf("encoded theme uses the deprecated key:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+/* ╭──────────────────────────────────────────╮ */
+/* │             THEME CATALOGUE              │ */
+/* ╰──────────────────────────────────────────╯ */
+
+var (
+	themesMu sync.RWMutex
+	themes   = map[string]func() *Theme{}
+)
+
+func init() {
+	RegisterTheme("default", DefaultTheme)
+	RegisterTheme("dracula", builtinTheme("dracula", draculaTokens))
+	RegisterTheme("nord", builtinTheme("nord", nordTokens))
+	RegisterTheme("solarized", builtinTheme("solarized", solarizedTokens))
+	RegisterTheme("gruvbox", builtinTheme("gruvbox", gruvboxTokens))
+	RegisterTheme("catppuccin", builtinTheme("catppuccin", catppuccinTokens))
+	RegisterTheme("high-contrast", builtinTheme("high-contrast", highContrastTokens))
+}
+
+// RegisterTheme adds a named theme to the catalogue, replacing any theme
+// already registered under that name. Names are case insensitive.
+func RegisterTheme(name string, build func() *Theme) {
+	themesMu.Lock()
+	defer themesMu.Unlock()
+	themes[strings.ToLower(name)] = build
+}
+
+// ThemeByName returns a fresh copy of a registered theme.
+func ThemeByName(name string) (*Theme, error) {
+	themesMu.RLock()
+	build, ok := themes[strings.ToLower(name)]
+	themesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ListThemes(), ", "))
+	}
+	return build(), nil
+}
+
+// ListThemes returns the names of every registered theme, sorted.
+func ListThemes() []string {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func builtinTheme(name string, tk Tokens) func() *Theme {
+	return func() *Theme {
+		t := NewThemeFromTokens(tk)
+		t.Name = name
+		return t
+	}
+}
+
+// https://draculatheme.com/contribute
+var draculaTokens = Tokens{
+	Primary:    solid("#BD93F9"),
+	Subtle:     solid("#44475A"),
+	Success:    solid("#50FA7B"),
+	Danger:     solid("#FF5555"),
+	Warning:    solid("#F1FA8C"),
+	Accent:     solid("#FF79C6"),
+	Info:       solid("#8BE9FD"),
+	Muted:      solid("#6272A4"),
+	Text:       solid("#F8F8F2"),
+	OnAccent:   solid("#282A36"),
+	Surface:    solid("#44475A"),
+	OnSurface:  solid("#F8F8F2"),
+	Background: solid("#282A36"),
+}
+
+// https://www.nordtheme.com/docs/colors-and-palettes
+var nordTokens = Tokens{
+	Primary:    lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+	Subtle:     lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#434C5E"},
+	Success:    solid("#A3BE8C"),
+	Danger:     solid("#BF616A"),
+	Warning:    solid("#EBCB8B"),
+	Accent:     solid("#B48EAD"),
+	Info:       solid("#81A1C1"),
+	Muted:      lipgloss.AdaptiveColor{Light: "#4C566A", Dark: "#616E88"},
+	Text:       lipgloss.AdaptiveColor{Light: "#2E3440", Dark: "#ECEFF4"},
+	OnAccent:   solid("#2E3440"),
+	Surface:    lipgloss.AdaptiveColor{Light: "#E5E9F0", Dark: "#3B4252"},
+	OnSurface:  lipgloss.AdaptiveColor{Light: "#2E3440", Dark: "#ECEFF4"},
+	Background: lipgloss.AdaptiveColor{Light: "#ECEFF4", Dark: "#2E3440"},
+}
+
+// https://ethanschoonover.com/solarized/
+var solarizedTokens = Tokens{
+	Primary:    solid("#6C71C4"),
+	Subtle:     lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"},
+	Success:    solid("#859900"),
+	Danger:     solid("#DC322F"),
+	Warning:    solid("#B58900"),
+	Accent:     solid("#D33682"),
+	Info:       solid("#268BD2"),
+	Muted:      lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586E75"},
+	Text:       lipgloss.AdaptiveColor{Light: "#586E75", Dark: "#93A1A1"},
+	OnAccent:   solid("#FDF6E3"),
+	Surface:    lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"},
+	OnSurface:  lipgloss.AdaptiveColor{Light: "#586E75", Dark: "#93A1A1"},
+	Background: lipgloss.AdaptiveColor{Light: "#FDF6E3", Dark: "#002B36"},
+}
+
+// https://github.com/morhetz/gruvbox
+var gruvboxTokens = Tokens{
+	Primary:    lipgloss.AdaptiveColor{Light: "#076678", Dark: "#83A598"},
+	Subtle:     lipgloss.AdaptiveColor{Light: "#D5C4A1", Dark: "#504945"},
+	Success:    lipgloss.AdaptiveColor{Light: "#79740E", Dark: "#B8BB26"},
+	Danger:     lipgloss.AdaptiveColor{Light: "#9D0006", Dark: "#FB4934"},
+	Warning:    lipgloss.AdaptiveColor{Light: "#B57614", Dark: "#FABD2F"},
+	Accent:     lipgloss.AdaptiveColor{Light: "#AF3A03", Dark: "#FE8019"},
+	Info:       lipgloss.AdaptiveColor{Light: "#427B58", Dark: "#8EC07C"},
+	Muted:      solid("#928374"),
+	Text:       lipgloss.AdaptiveColor{Light: "#3C3836", Dark: "#EBDBB2"},
+	OnAccent:   lipgloss.AdaptiveColor{Light: "#FBF1C7", Dark: "#282828"},
+	Surface:    lipgloss.AdaptiveColor{Light: "#EBDBB2", Dark: "#3C3836"},
+	OnSurface:  lipgloss.AdaptiveColor{Light: "#3C3836", Dark: "#EBDBB2"},
+	Background: lipgloss.AdaptiveColor{Light: "#FBF1C7", Dark: "#282828"},
+}
+
+// https://catppuccin.com/palette (Latte on light backgrounds, Mocha on dark).
+var catppuccinTokens = Tokens{
+	Primary:    lipgloss.AdaptiveColor{Light: "#8839EF", Dark: "#CBA6F7"},
+	Subtle:     lipgloss.AdaptiveColor{Light: "#CCD0DA", Dark: "#313244"},
+	Success:    lipgloss.AdaptiveColor{Light: "#40A02B", Dark: "#A6E3A1"},
+	Danger:     lipgloss.AdaptiveColor{Light: "#D20F39", Dark: "#F38BA8"},
+	Warning:    lipgloss.AdaptiveColor{Light: "#DF8E1D", Dark: "#F9E2AF"},
+	Accent:     lipgloss.AdaptiveColor{Light: "#EA76CB", Dark: "#F5C2E7"},
+	Info:       lipgloss.AdaptiveColor{Light: "#1E66F5", Dark: "#89B4FA"},
+	Muted:      lipgloss.AdaptiveColor{Light: "#9CA0B0", Dark: "#6C7086"},
+	Text:       lipgloss.AdaptiveColor{Light: "#4C4F69", Dark: "#CDD6F4"},
+	OnAccent:   lipgloss.AdaptiveColor{Light: "#EFF1F5", Dark: "#1E1E2E"},
+	Surface:    lipgloss.AdaptiveColor{Light: "#E6E9EF", Dark: "#45475A"},
+	OnSurface:  lipgloss.AdaptiveColor{Light: "#4C4F69", Dark: "#CDD6F4"},
+	Background: lipgloss.AdaptiveColor{Light: "#EFF1F5", Dark: "#1E1E2E"},
+}
+
+// Pure black and white plus the brightest primaries, for low-vision users and
+// terminals with washed out palettes.
+var highContrastTokens = Tokens{
+	Primary:    lipgloss.AdaptiveColor{Light: "#0000FF", Dark: "#FFFF00"},
+	Subtle:     lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+	Success:    lipgloss.AdaptiveColor{Light: "#006400", Dark: "#00FF00"},
+	Danger:     lipgloss.AdaptiveColor{Light: "#C00000", Dark: "#FF4040"},
+	Warning:    lipgloss.AdaptiveColor{Light: "#8B4000", Dark: "#FFA500"},
+	Accent:     lipgloss.AdaptiveColor{Light: "#800080", Dark: "#FF00FF"},
+	Info:       lipgloss.AdaptiveColor{Light: "#0000C0", Dark: "#00FFFF"},
+	Muted:      lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+	Text:       lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+	OnAccent:   lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+	Surface:    lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+	OnSurface:  lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+	Background: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+}
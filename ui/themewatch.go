@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type (
+	// ThemeChangedMsg carries a theme file that was just modified and parsed.
+	ThemeChangedMsg struct {
+		Path  string
+		Theme *Theme
+	}
+
+	// ThemeErrorMsg reports a theme file that could not be read or parsed.
+	// The previous theme should be kept; the watcher keeps going.
+	ThemeErrorMsg struct {
+		Path string
+		Err  error
+	}
+)
+
+// ThemeWatcher polls a theme file and reports changes as messages. Use it like
+// Tick: return Watch from Init and again every time one of its messages
+// arrives.
+//
+//	case ui.ThemeChangedMsg:
+//		m.theme = msg.Theme
+//		return m, m.watcher.Watch()
+//	case ui.ThemeErrorMsg:
+//		m.err = msg.Err
+//		return m, m.watcher.Watch()
+type ThemeWatcher struct {
+	Path string
+	// Interval between checks; zero or less checks twice per second.
+	Interval time.Duration
+
+	modTime  time.Time
+	size     int64
+	missing  bool
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// defaultWatchInterval is how often the file is checked when Interval is
+// not set.
+const defaultWatchInterval = 500 * time.Millisecond
+
+// NewThemeWatcher watches path, checking it twice per second. The file as it
+// is now counts as already loaded.
+func NewThemeWatcher(path string) *ThemeWatcher {
+	w := &ThemeWatcher{Path: path, Interval: defaultWatchInterval}
+	if info, err := os.Stat(path); err == nil {
+		w.modTime, w.size = info.ModTime(), info.Size()
+	} else {
+		w.missing = true
+	}
+	return w
+}
+
+// Watch waits until the file changes and returns a ThemeChangedMsg with the
+// new theme or a ThemeErrorMsg. It returns nil once Stop is called.
+func (w *ThemeWatcher) Watch() tea.Cmd {
+	done := w.stopped()
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	return func() tea.Msg {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return nil
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(w.Path)
+			if err != nil {
+				// Editors often replace the file on save, so only report
+				// the first failure and wait for it to come back.
+				if w.missing {
+					continue
+				}
+				w.missing = true
+				return ThemeErrorMsg{Path: w.Path, Err: err}
+			}
+			if !w.missing && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+				continue
+			}
+			w.missing = false
+			w.modTime, w.size = info.ModTime(), info.Size()
+
+			theme, err := LoadTheme(w.Path)
+			if err != nil {
+				return ThemeErrorMsg{Path: w.Path, Err: err}
+			}
+			return ThemeChangedMsg{Path: w.Path, Theme: theme}
+		}
+	}
+}
+
+// stopped returns the channel closed by Stop, making it on first use so a
+// watcher built without NewThemeWatcher works too.
+func (w *ThemeWatcher) stopped() chan struct{} {
+	w.doneOnce.Do(func() { w.done = make(chan struct{}) })
+	return w.done
+}
+
+// Stop ends any pending Watch command.
+func (w *ThemeWatcher) Stop() {
+	done := w.stopped()
+	select {
+	case <-done:
+	default:
+		close(done)
+	}
+}
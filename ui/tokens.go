@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/logrusorgru/aurora"
+)
+
+// Tokens are the semantic colors every palette slot, style and widget of the
+// library resolves through. Changing one token recolours everything built on
+// it. Use "#RRGGBB" values: gradients and animations blend them.
+type Tokens struct {
+	Primary    lipgloss.AdaptiveColor // Borders, tabs, dialog frames, descriptions.
+	Accent     lipgloss.AdaptiveColor // Active buttons, checkboxes, keywords.
+	Success    lipgloss.AdaptiveColor // Titles, check marks, links, 2xx statuses.
+	Warning    lipgloss.AdaptiveColor // 4xx statuses, warnings.
+	Danger     lipgloss.AdaptiveColor // Errors, 5xx statuses.
+	Info       lipgloss.AdaptiveColor // Status bar accents, 3xx statuses.
+	Muted      lipgloss.AdaptiveColor // Secondary text, suggestions, done items.
+	Subtle     lipgloss.AdaptiveColor // Dividers and faint lines.
+	Text       lipgloss.AdaptiveColor // Regular text on the terminal background.
+	OnAccent   lipgloss.AdaptiveColor // Text drawn over a filled accent color.
+	Surface    lipgloss.AdaptiveColor // Status bar and button backgrounds.
+	OnSurface  lipgloss.AdaptiveColor // Text drawn over Surface.
+	Background lipgloss.AdaptiveColor
+
+	// Overrides pins palette slots, by theme-file key ("button_background"),
+	// to a color of their own instead of their token. Pinned slots no longer
+	// follow the tokens, so keep them for the odd slot a design needs apart.
+	Overrides map[string]lipgloss.AdaptiveColor
+}
+
+// DefaultTokens returns the purple/green tokens of the default theme, taken
+// from the colors of the library's original palette.
+func DefaultTokens() Tokens {
+	return Tokens{
+		Primary:    lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"},
+		Accent:     lipgloss.AdaptiveColor{Light: "#E581A6", Dark: "#FF87D7"},
+		Success:    lipgloss.AdaptiveColor{Light: "#43BF6D", Dark: "#73F59F"},
+		Warning:    lipgloss.AdaptiveColor{Light: "#C98E0B", Dark: "#FDCC4B"},
+		Danger:     lipgloss.AdaptiveColor{Light: "#BF616A", Dark: "#F07178"},
+		Info:       lipgloss.AdaptiveColor{Light: "#377EC4", Dark: "#3F8EDD"},
+		Muted:      lipgloss.AdaptiveColor{Light: "#969B86", Dark: "#696969"},
+		Subtle:     lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#383838"},
+		Text:       lipgloss.AdaptiveColor{Light: "#343433", Dark: "#FAFAFA"},
+		OnAccent:   solid("#FFFDF5"),
+		Surface:    lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#353533"},
+		OnSurface:  lipgloss.AdaptiveColor{Light: "#343433", Dark: "#C1C6B2"},
+		Background: lipgloss.AdaptiveColor{Light: "#71fd4b", Dark: "#031935"},
+	}
+}
+
+// TokensFromPalette derives tokens from the palette slots they drive, so
+// what reads tokens follows a hand-made palette. Warning, which no slot
+// uses, keeps its default.
+func TokensFromPalette(p Palette) Tokens {
+	tk := DefaultTokens()
+	tk.Primary = p.Highlight
+	tk.Accent = p.Keyword
+	tk.Success = p.Special
+	tk.Danger = p.ErrorColor
+	tk.Info = p.StatusAccent
+	tk.Muted = p.Done
+	tk.Subtle = p.Subtle
+	tk.Text = p.PanelForeground
+	tk.OnAccent = p.StatusNugget
+	tk.Surface = p.StatusBarBackground
+	tk.OnSurface = p.StatusBarForeground
+	tk.Background = p.PanelBackground
+	return tk
+}
+
+// Palette spreads the tokens over every palette slot, then applies the
+// overrides.
+func (tk Tokens) Palette() Palette {
+	p := Palette{
+		Highlight:       tk.Primary,
+		Subtle:          tk.Subtle,
+		Special:         tk.Success,
+		ErrorColor:      tk.Danger,
+		PanelBackground: tk.Background,
+		PanelForeground: tk.Text,
+		Done:            tk.Muted,
+		ActiveBorder:    tk.Success,
+		InactiveBorder:  tk.Muted,
+		CompactBorder:   tk.Subtle,
+
+		Title:       tk.Success,
+		Description: tk.Primary,
+
+		DialogBorder:           tk.Primary,
+		ButtonForeground:       tk.OnAccent,
+		ButtonBackground:       tk.Muted,
+		ActiveButtonBackground: tk.Accent,
+		WidthInfoForeground:    tk.OnAccent,
+		WidthInfoBackground:    tk.Accent,
+
+		StatusBarForeground:   tk.OnSurface,
+		StatusBarBackground:   tk.Surface,
+		StatusNugget:          tk.OnAccent,
+		StatusAccent:          tk.Info,
+		StatusBoardForeground: tk.Background,
+		StatusBoardBackground: tk.Text,
+		StatusList:            tk.Info,
+		StatusUsername:        tk.Primary,
+
+		HoursForeground: tk.OnAccent,
+		HoursBackground: tk.Danger,
+
+		BrandForeground: tk.Info,
+		BrandBackground: tk.Success,
+
+		UserInput:  tk.Text,
+		Suggestion: tk.Muted,
+
+		TableSubtle:     tk.Muted,
+		TableForeground: tk.Accent,
+		TableBorder:     tk.Primary,
+
+		Keyword:    tk.Accent,
+		SubtleText: tk.Muted,
+		Ticks:      tk.Success,
+		Checkbox:   tk.Accent,
+		Dot:        tk.Subtle,
+		RampStart:  tk.Primary.Dark,
+		RampEnd:    tk.Success.Dark,
+
+		HEX: defaultHEX(),
+	}
+	slots := colorFields(&p)
+	for key, c := range tk.Overrides {
+		switch v, ok := slots[key]; {
+		case !ok:
+		case v.Kind() == reflect.String:
+			v.SetString(c.Dark)
+		default:
+			v.Set(reflect.ValueOf(c))
+		}
+	}
+	return p
+}
+
+// NewThemeFromTokens builds a theme whose every style resolves through tk.
+func NewThemeFromTokens(tk Tokens) *Theme {
+	t := NewTheme(tk.Palette())
+	// Build again, for the styles reading tokens no palette slot carries.
+	t.Tokens = tk
+	t.build()
+	return t
+}
+
+// Color picks the light or dark variant of c for the current terminal.
+func (t *Theme) Color(c lipgloss.AdaptiveColor) string {
+	if t.renderer.HasDarkBackground() {
+		return c.Dark
+	}
+	return c.Light
+}
+
+// hexRGBRE matches the colors StatusColor can measure.
+var hexRGBRE = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// StatusColor renders an HTTP status code as a badge colored with the
+// Danger, Warning, Info or Success token.
+func (t *Theme) StatusColor(status int) aurora.Value {
+	var token lipgloss.AdaptiveColor
+	switch {
+	case status >= http.StatusInternalServerError:
+		token = t.Tokens.Danger
+	case status >= http.StatusBadRequest:
+		token = t.Tokens.Warning
+	case status >= http.StatusMultipleChoices:
+		token = t.Tokens.Info
+	default:
+		token = t.Tokens.Success
+	}
+	bg := t.Color(token)
+	// Only "#RRGGBB" tokens can be measured; ANSI codes keep white text.
+	fg := "#FFFFFF"
+	if hexRGBRE.MatchString(bg) && brightness(hexToRGB(bg)) > 128 {
+		fg = "#000000"
+	}
+	statusSpaced := " " + strconv.Itoa(status) + " "
+	return auroraColors(statusSpaced, fg, bg, t.ColorProfile())
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestPrimaryTokenRecolours(t *testing.T) {
+	tk := DefaultTokens()
+	tk.Primary = solid("#123456")
+	p := tk.Palette()
+	for name, got := range map[string]lipgloss.AdaptiveColor{
+		"DialogBorder": p.DialogBorder,
+		"Description":  p.Description,
+		"TableBorder":  p.TableBorder,
+		"Highlight":    p.Highlight,
+	} {
+		if got != tk.Primary {
+			t.Errorf("%s = %v, want the Primary token %v", name, got, tk.Primary)
+		}
+	}
+	th := NewThemeFromTokens(tk)
+	if got := th.DialogBoxStyle.GetBorderTopForeground(); got != tk.Primary {
+		t.Errorf("DialogBoxStyle border = %v, want %v", got, tk.Primary)
+	}
+}
+
+func TestAccentTokenRecolours(t *testing.T) {
+	tk := DefaultTokens()
+	tk.Accent = solid("#654321")
+	p := tk.Palette()
+	if p.Checkbox != tk.Accent || p.Keyword != tk.Accent || p.ActiveButtonBackground != tk.Accent {
+		t.Errorf("Checkbox, Keyword and ActiveButtonBackground do not follow Accent: %v %v %v", p.Checkbox, p.Keyword, p.ActiveButtonBackground)
+	}
+}
+
+func TestTokenOverrides(t *testing.T) {
+	tk := DefaultTokens()
+	if len(tk.Overrides) != 0 {
+		t.Fatalf("default tokens pin %d slots", len(tk.Overrides))
+	}
+	tk.Overrides = map[string]lipgloss.AdaptiveColor{
+		"table_border": solid("#abcdef"),
+		"ramp_start":   solid("#000000"),
+	}
+	p := tk.Palette()
+	if p.TableBorder != solid("#abcdef") || p.RampStart != "#000000" {
+		t.Errorf("overrides not applied: %v %v", p.TableBorder, p.RampStart)
+	}
+	if p.DialogBorder != tk.Primary {
+		t.Errorf("DialogBorder = %v, want the Primary token", p.DialogBorder)
+	}
+}
+
+func TestStatusColorANSIToken(t *testing.T) {
+	tk := DefaultTokens()
+	tk.Success = solid("212")
+	th := NewThemeFromTokens(tk).WithColorProfile(termenv.ANSI256)
+	got := th.StatusColor(200).String()
+	if want := auroraColors(" 200 ", "#FFFFFF", "212", termenv.ANSI256).String(); got != want {
+		t.Errorf("StatusColor(200) = %q, want white text %q", got, want)
+	}
+}
+
+func TestMessageStylesFollowTokens(t *testing.T) {
+	tk := DefaultTokens()
+	tk.Danger = solid("#aa0000")
+	tk.Warning = solid("#aaaa00")
+	th := NewThemeFromTokens(tk)
+	if got := th.ErrorStyle.GetForeground(); got != tk.Danger {
+		t.Errorf("ErrorStyle = %v, want %v", got, tk.Danger)
+	}
+	if got := th.WarningStyle.GetForeground(); got != tk.Warning {
+		t.Errorf("WarningStyle = %v, want %v", got, tk.Warning)
+	}
+}
@@ -18,18 +18,11 @@ func ResetColor() string {
 	return "\033[0m"
 }
 func TruncateString(input string, length int, ellipsis string, finalString string) string {
-
-	if len(input) > length {
-		return input[:length-len(ellipsis)] + ellipsis
-	}
-
-	var sb strings.Builder
-	// Add the rest of the spaces and  then the final string
-	for i := len(input); i < length; i++ {
-		sb.WriteString(" ")
+	if StringWidth(input) > length {
+		return Truncate(input, length, ellipsis)
 	}
-
-	return fmt.Sprint(input+sb.String(), string(Reset), finalString)
+	// Add the rest of the spaces and then the final string
+	return PadRight(input, length) + Reset + finalString
 }
 func WaitForLoading() tea.Cmd {
 	return func() tea.Msg {
@@ -125,6 +118,9 @@ func CenterBlockText(text string, width int) string {
 func ClearScreen() {
 	fmt.Print("\033[2J\033[1;1H")
 }
+
+// Float64FromString returns 0 for anything that is not a number; use
+// ParseFloat to tell bad input apart.
 func Float64FromString(s string) float64 {
 	f, _ := strconv.ParseFloat(s, 64)
 	return f
@@ -242,6 +238,10 @@ func resetColor() string {
 /* ╰──────────────────────────────────────────╯ */
 // "github.com/alexeyco/simpletable"
 // Level 4: Aux
+//
+// GetBoxWithShadowEffectUI draws a one-line box with a shadow. To cast the
+// same shadow under any block, over other content, use a Compositor layer
+// with ShadowLine.
 func GetBoxWithShadowEffectUI(icon, text string) string {
 	var sb strings.Builder
 
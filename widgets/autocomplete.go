@@ -0,0 +1,367 @@
+package widgets
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+// SuggestionsMsg carries the suggestions an Autocomplete's AsyncProvider
+// found for Query. Replies for a query that is no longer typed are dropped.
+type SuggestionsMsg struct {
+	Query string
+	Items []string
+}
+
+// AutocompleteKeyMap holds the key bindings of an Autocomplete.
+type AutocompleteKeyMap struct {
+	Up, Down key.Binding
+	// Accept completes the text with the highlighted suggestion.
+	Accept key.Binding
+	// AcceptGhost completes the text with the inline ghost, only with the
+	// cursor at the end of the text.
+	AcceptGhost key.Binding
+	Dismiss     key.Binding
+}
+
+// DefaultAutocompleteKeyMap returns the default bindings.
+func DefaultAutocompleteKeyMap() AutocompleteKeyMap {
+	return AutocompleteKeyMap{
+		Up:          key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("↑", "previous")),
+		Down:        key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("↓", "next")),
+		Accept:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "complete")),
+		AcceptGhost: key.NewBinding(key.WithKeys("right", "ctrl+e"), key.WithHelp("→", "complete")),
+		Dismiss:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+	}
+}
+
+// Autocomplete is a text input that suggests completions as you type: the
+// best one inline as a ghost in the theme's SuggestionStyle, and a dropdown
+// of the fuzzy-ranked matches with the matched characters highlighted.
+//
+// Suggestions come from Items, from Provider on every change, or from
+// AsyncProvider, whose command replies with a SuggestionsMsg:
+//
+//	ac.AsyncProvider = func(q string) tea.Cmd {
+//		return func() tea.Msg {
+//			return widgets.SuggestionsMsg{Query: q, Items: searchBoards(q)}
+//		}
+//	}
+type Autocomplete struct {
+	KeyMap AutocompleteKeyMap
+	Prompt string
+	// Items is the static list of suggestions.
+	Items []string
+	// Provider returns the suggestions for the typed text.
+	Provider func(query string) []string
+	// AsyncProvider returns a command looking up the suggestions for the
+	// typed text.
+	AsyncProvider func(query string) tea.Cmd
+	// MaxResults is the number of rows of the dropdown.
+	MaxResults int
+
+	theme      *txui.Theme
+	input      textinput.Model
+	candidates []string
+	matches    []fuzzyMatch
+	selected   int
+	open       bool
+}
+
+// NewAutocomplete returns a focused, empty input suggesting items. A nil
+// theme uses ui.DefaultTheme.
+func NewAutocomplete(theme *txui.Theme, items ...string) Autocomplete {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	ti := txui.InitTI()
+	ti.TextStyle = theme.UserInputStyle
+	ti.PlaceholderStyle = theme.SuggestionStyle
+	ti.CompletionStyle = theme.SuggestionStyle
+	ti.ShowSuggestions = true
+	// The ghost follows the dropdown, so its keys are handled here.
+	ti.KeyMap.AcceptSuggestion.SetEnabled(false)
+	ti.KeyMap.NextSuggestion.SetEnabled(false)
+	ti.KeyMap.PrevSuggestion.SetEnabled(false)
+	return Autocomplete{
+		KeyMap:     DefaultAutocompleteKeyMap(),
+		Items:      items,
+		MaxResults: 6,
+		theme:      theme,
+		input:      ti,
+	}
+}
+
+// Init starts the cursor blinking.
+func (m Autocomplete) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Focus makes the input react to keys.
+func (m *Autocomplete) Focus() tea.Cmd {
+	return m.input.Focus()
+}
+
+// Blur makes the input ignore keys and closes the dropdown.
+func (m *Autocomplete) Blur() {
+	m.input.Blur()
+	m.close()
+}
+
+// Focused reports whether the input reacts to keys.
+func (m Autocomplete) Focused() bool {
+	return m.input.Focused()
+}
+
+// Value returns the typed text.
+func (m Autocomplete) Value() string {
+	return m.input.Value()
+}
+
+// SetValue replaces the text without suggesting anything.
+func (m *Autocomplete) SetValue(s string) {
+	m.input.SetValue(s)
+	m.input.CursorEnd()
+	m.close()
+}
+
+// SetPlaceholder sets the text shown while the input is empty.
+func (m *Autocomplete) SetPlaceholder(s string) {
+	m.input.Placeholder = s
+}
+
+// Suggestions returns the matches shown in the dropdown, best first.
+func (m Autocomplete) Suggestions() []string {
+	if !m.open {
+		return nil
+	}
+	out := make([]string, len(m.matches))
+	for i, fm := range m.matches {
+		out[i] = fm.text
+	}
+	return out
+}
+
+func (m *Autocomplete) close() {
+	m.open = false
+	m.matches = nil
+	m.selected = 0
+	m.input.SetSuggestions(nil)
+}
+
+// changed looks up the suggestions for the new text.
+func (m *Autocomplete) changed() tea.Cmd {
+	query := m.input.Value()
+	if query == "" {
+		m.close()
+		return nil
+	}
+	var cmd tea.Cmd
+	switch {
+	case m.AsyncProvider != nil:
+		// Keep ranking the last reply until the new one arrives.
+		cmd = m.AsyncProvider(query)
+	case m.Provider != nil:
+		m.candidates = m.Provider(query)
+	default:
+		m.candidates = m.Items
+	}
+	m.open = true
+	m.rank()
+	return cmd
+}
+
+// rank matches the candidates against the text and updates the ghost.
+func (m *Autocomplete) rank() {
+	m.matches = rankFuzzy(m.input.Value(), m.candidates)
+	if m.MaxResults > 0 && len(m.matches) > m.MaxResults {
+		m.matches = m.matches[:m.MaxResults]
+	}
+	m.selected = 0
+	m.ghost()
+}
+
+// ghost shows the highlighted suggestion inline when it starts with the
+// text.
+func (m *Autocomplete) ghost() {
+	query := strings.ToLower(m.input.Value())
+	if m.open && m.selected < len(m.matches) {
+		if s := m.matches[m.selected].text; strings.HasPrefix(strings.ToLower(s), query) {
+			m.input.SetSuggestions([]string{s})
+			return
+		}
+	}
+	m.input.SetSuggestions(nil)
+}
+
+// accept completes the text with s.
+func (m *Autocomplete) accept(s string) {
+	m.input.SetValue(s)
+	m.input.CursorEnd()
+	m.close()
+}
+
+// Update edits the text, moves through the suggestions and accepts them.
+func (m Autocomplete) Update(msg tea.Msg) (Autocomplete, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SuggestionsMsg:
+		if msg.Query == m.input.Value() && m.open {
+			m.candidates = msg.Items
+			m.rank()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if !m.input.Focused() {
+			return m, nil
+		}
+		atEnd := m.input.Position() == len([]rune(m.input.Value()))
+		switch {
+		case key.Matches(msg, m.KeyMap.Up) && m.open && len(m.matches) > 0:
+			m.selected = (m.selected - 1 + len(m.matches)) % len(m.matches)
+			m.ghost()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Down) && m.open && len(m.matches) > 0:
+			m.selected = (m.selected + 1) % len(m.matches)
+			m.ghost()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Accept) && m.open && len(m.matches) > 0:
+			m.accept(m.matches[m.selected].text)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.AcceptGhost) && atEnd && m.input.CurrentSuggestion() != "":
+			m.accept(m.input.CurrentSuggestion())
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Dismiss) && m.open:
+			m.close()
+			return m, nil
+		}
+	}
+	before := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != before {
+		return m, tea.Batch(cmd, m.changed())
+	}
+	// The input re-matches its suggestions on every key.
+	m.ghost()
+	return m, cmd
+}
+
+// View renders the input and, while there are matches, the dropdown.
+func (m Autocomplete) View() string {
+	t := m.theme
+	view := m.Prompt + m.input.View()
+	if !m.open || len(m.matches) == 0 {
+		return view
+	}
+	var sb strings.Builder
+	sb.WriteString(view)
+	for i, fm := range m.matches {
+		cursor, rest := "  ", t.SuggestionStyle
+		if i == m.selected {
+			cursor, rest = t.CheckboxStyle.Render("> "), t.UserInputStyle
+		}
+		sb.WriteString("\n" + cursor)
+		runes := []rune(fm.text)
+		hit := 0
+		for j := 0; j < len(runes); {
+			// Render runs of matched and unmatched characters at once.
+			matched := hit < len(fm.idx) && fm.idx[hit] == j
+			k := j
+			for k < len(runes) && (hit < len(fm.idx) && fm.idx[hit] == k) == matched {
+				if matched {
+					hit++
+				}
+				k++
+			}
+			if matched {
+				sb.WriteString(t.KeywordStyle.Render(string(runes[j:k])))
+			} else {
+				sb.WriteString(rest.Render(string(runes[j:k])))
+			}
+			j = k
+		}
+	}
+	return sb.String()
+}
+
+/* ╭──────────────────────────────────────────╮ */
+/* │              FUZZY MATCHING              │ */
+/* ╰──────────────────────────────────────────╯ */
+
+// fuzzyMatch is a candidate holding every character of a query in order.
+type fuzzyMatch struct {
+	text  string
+	idx   []int // Rune indexes of the matched characters.
+	score int
+}
+
+// Scores of fuzzy matches: matches at the start of the text or of a word,
+// and runs of consecutive characters, rank higher; gaps rank lower.
+const (
+	fuzzyHit         = 1
+	fuzzyConsecutive = 5
+	fuzzyWordStart   = 8
+	fuzzyPrefix      = 10
+	fuzzyGap         = 1
+)
+
+// matchFuzzy matches query against s, ignoring case. It reports false when
+// some character of query is missing.
+func matchFuzzy(query, s string) (fuzzyMatch, bool) {
+	q := []rune(strings.ToLower(query))
+	runes := []rune(s)
+	fm := fuzzyMatch{text: s}
+	qi, last := 0, -1
+	for i := 0; i < len(runes) && qi < len(q); i++ {
+		if unicode.ToLower(runes[i]) != q[qi] {
+			continue
+		}
+		fm.score += fuzzyHit
+		switch {
+		case i == 0:
+			fm.score += fuzzyPrefix + fuzzyWordStart
+		case last == i-1:
+			fm.score += fuzzyConsecutive
+		case isWordStart(runes, i):
+			fm.score += fuzzyWordStart
+		}
+		if last >= 0 {
+			fm.score -= fuzzyGap * (i - last - 1)
+		}
+		fm.idx = append(fm.idx, i)
+		last = i
+		qi++
+	}
+	return fm, qi == len(q)
+}
+
+func isWordStart(runes []rune, i int) bool {
+	prev := runes[i-1]
+	if unicode.IsLower(prev) && unicode.IsUpper(runes[i]) {
+		return true
+	}
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+}
+
+// rankFuzzy returns the candidates matching query, best first; shorter ones
+// win ties.
+func rankFuzzy(query string, candidates []string) []fuzzyMatch {
+	var matches []fuzzyMatch
+	for _, c := range candidates {
+		if fm, ok := matchFuzzy(query, c); ok {
+			matches = append(matches, fm)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return len(matches[i].text) < len(matches[j].text)
+	})
+	return matches
+}
@@ -0,0 +1,256 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+// CheckboxItem is one entry of a CheckboxList.
+type CheckboxItem struct {
+	Label    string
+	Checked  bool
+	Disabled bool // Shown but neither focusable nor toggleable.
+}
+
+// CheckboxListKeyMap holds the key bindings of a CheckboxList. Letters are
+// left free for filtering.
+type CheckboxListKeyMap struct {
+	Up, Down    key.Binding
+	Toggle      key.Binding
+	All, None   key.Binding
+	Invert      key.Binding
+	ClearFilter key.Binding
+}
+
+// DefaultCheckboxListKeyMap returns the default bindings.
+func DefaultCheckboxListKeyMap() CheckboxListKeyMap {
+	return CheckboxListKeyMap{
+		Up:          key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("↑", "up")),
+		Down:        key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("↓", "down")),
+		Toggle:      key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "toggle")),
+		All:         key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "select all")),
+		None:        key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "select none")),
+		Invert:      key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "invert")),
+		ClearFilter: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear filter")),
+	}
+}
+
+// CheckboxList is a multi-select list. Typing filters the items, the
+// bulk actions apply to the items matching the filter.
+type CheckboxList struct {
+	KeyMap CheckboxListKeyMap
+	// Height is the number of items shown at once; zero shows them all.
+	Height int
+
+	theme   *txui.Theme
+	items   []CheckboxItem
+	visible []int // Indexes of the items matching the filter.
+	cursor  int   // Position in visible.
+	offset  int   // First position of visible shown.
+	filter  string
+	focused bool
+}
+
+// NewCheckboxList returns a focused list of items. A nil theme uses
+// ui.DefaultTheme.
+func NewCheckboxList(theme *txui.Theme, items ...CheckboxItem) CheckboxList {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	m := CheckboxList{KeyMap: DefaultCheckboxListKeyMap(), theme: theme, focused: true}
+	m.SetItems(items)
+	return m
+}
+
+// Init implements tea.Model.
+func (m CheckboxList) Init() tea.Cmd {
+	return nil
+}
+
+// SetItems replaces the items, keeping the filter.
+func (m *CheckboxList) SetItems(items []CheckboxItem) {
+	m.items = append([]CheckboxItem(nil), items...)
+	m.refilter()
+}
+
+// Items returns every item with its state.
+func (m CheckboxList) Items() []CheckboxItem {
+	return append([]CheckboxItem(nil), m.items...)
+}
+
+// Selected returns the indexes of the checked items.
+func (m CheckboxList) Selected() []int {
+	var sel []int
+	for i, it := range m.items {
+		if it.Checked {
+			sel = append(sel, i)
+		}
+	}
+	return sel
+}
+
+// SelectedLabels returns the labels of the checked items.
+func (m CheckboxList) SelectedLabels() []string {
+	var sel []string
+	for _, i := range m.Selected() {
+		sel = append(sel, m.items[i].Label)
+	}
+	return sel
+}
+
+// Filter returns the text typed to filter the items.
+func (m CheckboxList) Filter() string {
+	return m.filter
+}
+
+// Focus makes the list react to keys.
+func (m *CheckboxList) Focus() {
+	m.focused = true
+}
+
+// Blur makes the list ignore keys.
+func (m *CheckboxList) Blur() {
+	m.focused = false
+}
+
+// Focused reports whether the list reacts to keys.
+func (m CheckboxList) Focused() bool {
+	return m.focused
+}
+
+// Update handles the keys while the list is focused.
+func (m CheckboxList) Update(msg tea.Msg) (CheckboxList, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focused {
+		return m, nil
+	}
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.Up):
+		m.move(-1)
+	case key.Matches(keyMsg, m.KeyMap.Down):
+		m.move(1)
+	case key.Matches(keyMsg, m.KeyMap.Toggle):
+		if i, ok := m.current(); ok {
+			m.items = m.Items()
+			m.items[i].Checked = !m.items[i].Checked
+		}
+	case key.Matches(keyMsg, m.KeyMap.All):
+		m.setVisible(func(bool) bool { return true })
+	case key.Matches(keyMsg, m.KeyMap.None):
+		m.setVisible(func(bool) bool { return false })
+	case key.Matches(keyMsg, m.KeyMap.Invert):
+		m.setVisible(func(c bool) bool { return !c })
+	case key.Matches(keyMsg, m.KeyMap.ClearFilter):
+		m.filter = ""
+		m.refilter()
+	case keyMsg.Type == tea.KeyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			m.filter = string(r[:len(r)-1])
+			m.refilter()
+		}
+	case keyMsg.Type == tea.KeyRunes && !keyMsg.Alt:
+		m.filter += string(keyMsg.Runes)
+		m.refilter()
+	}
+	return m, nil
+}
+
+// current returns the item under the cursor, if it can be toggled.
+func (m CheckboxList) current() (int, bool) {
+	if m.cursor >= len(m.visible) {
+		return 0, false
+	}
+	i := m.visible[m.cursor]
+	return i, !m.items[i].Disabled
+}
+
+// move steps the cursor by dir over the enabled visible items.
+func (m *CheckboxList) move(dir int) {
+	for c := m.cursor + dir; c >= 0 && c < len(m.visible); c += dir {
+		if !m.items[m.visible[c]].Disabled {
+			m.cursor = c
+			break
+		}
+	}
+	m.scroll()
+}
+
+// setVisible sets every enabled visible item to f of its state.
+func (m *CheckboxList) setVisible(f func(checked bool) bool) {
+	m.items = m.Items()
+	for _, i := range m.visible {
+		if !m.items[i].Disabled {
+			m.items[i].Checked = f(m.items[i].Checked)
+		}
+	}
+}
+
+// refilter recomputes the visible items and puts the cursor on the first
+// enabled one.
+func (m *CheckboxList) refilter() {
+	m.visible = nil
+	needle := strings.ToLower(m.filter)
+	for i, it := range m.items {
+		if strings.Contains(strings.ToLower(it.Label), needle) {
+			m.visible = append(m.visible, i)
+		}
+	}
+	m.cursor, m.offset = 0, 0
+	if len(m.visible) > 0 && m.items[m.visible[0]].Disabled {
+		m.move(1)
+	}
+	m.scroll()
+}
+
+// scroll keeps the cursor within the shown items.
+func (m *CheckboxList) scroll() {
+	if m.Height <= 0 {
+		m.offset = 0
+		return
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.Height {
+		m.offset = m.cursor - m.Height + 1
+	}
+}
+
+// View renders the filter, the items and how many are selected.
+func (m CheckboxList) View() string {
+	t := m.theme
+	var lines []string
+	if m.filter != "" {
+		lines = append(lines, t.SubtleStyle.Render("filter: ")+t.UserInputStyle.Render(m.filter))
+	}
+	end := len(m.visible)
+	if m.Height > 0 {
+		end = min(end, m.offset+m.Height)
+	}
+	for c := m.offset; c < end; c++ {
+		it := m.items[m.visible[c]]
+		cursor := "  "
+		if c == m.cursor && m.focused {
+			cursor = t.CheckboxStyle.Render("> ")
+		}
+		var row string
+		switch {
+		case it.Disabled && it.Checked:
+			row = t.SubtleStyle.Render("[x] " + it.Label)
+		case it.Disabled:
+			row = t.SubtleStyle.Render("[ ] " + it.Label)
+		default:
+			row = t.Checkbox(it.Label, it.Checked)
+		}
+		lines = append(lines, cursor+row)
+	}
+	if len(m.visible) == 0 {
+		lines = append(lines, t.SubtleStyle.Render("  no matches"))
+	}
+	lines = append(lines, t.CheckMark()+t.SubtleStyle.Render(fmt.Sprintf("%d/%d selected", len(m.Selected()), len(m.items))))
+	return strings.Join(lines, "\n")
+}
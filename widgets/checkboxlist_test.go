@@ -0,0 +1,30 @@
+package widgets
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCheckboxListUpdateKeepsOldModel(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.KeyMsg
+	}{
+		{"toggle", tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}},
+		{"all", tea.KeyMsg{Type: tea.KeyCtrlA}},
+		{"invert", tea.KeyMsg{Type: tea.KeyCtrlT}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := NewCheckboxList(nil, CheckboxItem{Label: "a"}, CheckboxItem{Label: "b"})
+			after, _ := before.Update(tt.msg)
+			if len(after.Selected()) == 0 {
+				t.Fatalf("%s checked nothing", tt.name)
+			}
+			if sel := before.Selected(); len(sel) != 0 {
+				t.Errorf("%s changed the previous model: %v checked", tt.name, sel)
+			}
+		})
+	}
+}
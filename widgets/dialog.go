@@ -0,0 +1,317 @@
+package widgets
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	txui "txeo-tui-library/ui"
+)
+
+// DialogResultMsg is sent when a dialog closes.
+type DialogResultMsg struct {
+	// ID of the dialog that closed.
+	ID string
+	// Button is the index of the pressed button, or of the cancel button
+	// when the dialog was dismissed with Esc.
+	Button int
+	Label  string
+	// Canceled reports whether the dialog was dismissed with Esc or closed
+	// with its cancel button.
+	Canceled bool
+	// Value is the text typed in a prompt dialog.
+	Value string
+}
+
+// DialogKeyMap holds the key bindings of a Dialog.
+type DialogKeyMap struct {
+	Next, Prev key.Binding
+	Press      key.Binding
+	Cancel     key.Binding
+}
+
+// DefaultDialogKeyMap returns the default bindings. In prompt dialogs the
+// arrows move the cursor of the input instead.
+func DefaultDialogKeyMap() DialogKeyMap {
+	return DialogKeyMap{
+		Next:   key.NewBinding(key.WithKeys("tab", "right", "l"), key.WithHelp("tab", "next button")),
+		Prev:   key.NewBinding(key.WithKeys("shift+tab", "left", "h"), key.WithHelp("shift+tab", "previous button")),
+		Press:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "press")),
+		Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+// Dialog is a modal box with a title, a body and a row of buttons, drawn
+// with the theme's DialogBoxStyle, ButtonStyle and ActiveButtonStyle. Open
+// it on a Dialogs manager, which shows it over the rest of the view.
+type Dialog struct {
+	ID      string
+	KeyMap  DialogKeyMap
+	Title   string
+	Body    string
+	Buttons []string
+	// Default is the button focused when the dialog opens.
+	Default int
+	// Cancel is the button Esc resolves to; -1 makes Esc do nothing.
+	Cancel int
+	// Width of the body, 50 if zero.
+	Width int
+
+	theme  *txui.Theme
+	input  textinput.Model
+	prompt bool
+	focus  int
+}
+
+// NewDialog returns a dialog with custom content and buttons. Esc does
+// nothing until Cancel is set. A nil theme uses ui.DefaultTheme.
+func NewDialog(theme *txui.Theme, id, title, body string, buttons ...string) Dialog {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return Dialog{
+		ID:      id,
+		KeyMap:  DefaultDialogKeyMap(),
+		Title:   title,
+		Body:    body,
+		Buttons: buttons,
+		Cancel:  -1,
+		theme:   theme,
+	}
+}
+
+// NewConfirmDialog returns a Yes/No dialog; Esc answers No.
+func NewConfirmDialog(theme *txui.Theme, id, title, question string) Dialog {
+	d := NewDialog(theme, id, title, question, "Yes", "No")
+	d.Cancel = 1
+	return d
+}
+
+// NewAlertDialog returns a dialog with a single OK button; Esc closes it.
+func NewAlertDialog(theme *txui.Theme, id, title, text string) Dialog {
+	d := NewDialog(theme, id, title, text, "OK")
+	d.Cancel = 0
+	return d
+}
+
+// NewPromptDialog returns an OK/Cancel dialog asking for a line of text,
+// reported in DialogResultMsg.Value. Enter presses OK unless Cancel is
+// focused.
+func NewPromptDialog(theme *txui.Theme, id, title, text, placeholder string) Dialog {
+	d := NewDialog(theme, id, title, text, "OK", "Cancel")
+	d.Cancel = 1
+	d.prompt = true
+	d.input = txui.InitTI()
+	d.input.Placeholder = placeholder
+	d.input.TextStyle = d.theme.UserInputStyle
+	d.input.PlaceholderStyle = d.theme.SuggestionStyle
+	return d
+}
+
+// Value returns the text typed in a prompt dialog.
+func (d Dialog) Value() string {
+	return d.input.Value()
+}
+
+// SetValue sets the text of a prompt dialog.
+func (d *Dialog) SetValue(s string) {
+	d.input.SetValue(s)
+	d.input.CursorEnd()
+}
+
+// Init starts the cursor of prompt dialogs blinking.
+func (d Dialog) Init() tea.Cmd {
+	if d.prompt {
+		return textinput.Blink
+	}
+	return nil
+}
+
+// result returns the message closing the dialog with button i.
+func (d Dialog) result(i int) tea.Cmd {
+	msg := DialogResultMsg{ID: d.ID, Button: i, Canceled: d.Cancel >= 0 && i == d.Cancel}
+	if i >= 0 && i < len(d.Buttons) {
+		msg.Label = d.Buttons[i]
+	}
+	if d.prompt {
+		msg.Value = d.input.Value()
+	}
+	return func() tea.Msg { return msg }
+}
+
+// Update moves the focus between buttons and presses them. It returns the
+// command sending DialogResultMsg and true once the dialog is closed.
+func (d Dialog) Update(msg tea.Msg) (Dialog, tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		if d.prompt {
+			d.input, cmd = d.input.Update(msg)
+		}
+		return d, cmd, false
+	}
+	arrow := keyMsg.Type == tea.KeyLeft || keyMsg.Type == tea.KeyRight
+	switch {
+	case key.Matches(keyMsg, d.KeyMap.Cancel):
+		if d.Cancel >= 0 {
+			return d, d.result(d.Cancel), true
+		}
+		return d, nil, false
+	case key.Matches(keyMsg, d.KeyMap.Press):
+		if len(d.Buttons) == 0 {
+			return d, d.result(-1), true
+		}
+		return d, d.result(d.focus), true
+	case d.prompt && (arrow || keyMsg.Type == tea.KeyRunes):
+		// Typing goes to the input.
+	case key.Matches(keyMsg, d.KeyMap.Next) && len(d.Buttons) > 0:
+		d.focus = (d.focus + 1) % len(d.Buttons)
+		return d, nil, false
+	case key.Matches(keyMsg, d.KeyMap.Prev) && len(d.Buttons) > 0:
+		d.focus = (d.focus - 1 + len(d.Buttons)) % len(d.Buttons)
+		return d, nil, false
+	}
+	var cmd tea.Cmd
+	if d.prompt {
+		d.input, cmd = d.input.Update(msg)
+	}
+	return d, cmd, false
+}
+
+// View renders the dialog box.
+func (d Dialog) View() string {
+	t := d.theme
+	width := d.Width
+	if width <= 0 {
+		width = 50
+	}
+	var parts []string
+	if d.Title != "" {
+		parts = append(parts, t.TitleStyle.Margin(0, 0, 1).Width(width).Align(lipgloss.Center).Render(d.Title))
+	}
+	if d.Body != "" {
+		parts = append(parts, t.DialogStyle.Width(width).Render(d.Body))
+	}
+	if d.prompt {
+		// Leave room for the border, the padding and the cursor.
+		d.input.Width = width - 7
+		input := t.BaseBorderedStyle.Padding(0, 1).Width(width - 2).Render(d.input.View())
+		parts = append(parts, input)
+	}
+	buttons := make([]string, len(d.Buttons))
+	for i, label := range d.Buttons {
+		style := t.ButtonStyle
+		if i == d.focus {
+			style = t.ActiveButtonStyle
+		}
+		if i < len(d.Buttons)-1 {
+			style = style.MarginRight(2)
+		} else {
+			style = style.MarginRight(0)
+		}
+		buttons[i] = style.Render(label)
+	}
+	if len(buttons) > 0 {
+		parts = append(parts, lipgloss.JoinHorizontal(lipgloss.Top, buttons...))
+	}
+	content := lipgloss.JoinVertical(lipgloss.Center, parts...)
+	return t.DialogBoxStyle.Render(lipgloss.PlaceHorizontal(width+2, lipgloss.Center, content))
+}
+
+// Dialogs stacks modal dialogs over an application: the topmost one gets the
+// keys and is drawn centered over the rest of the view.
+//
+//	case tea.WindowSizeMsg:
+//		m.dialogs, _ = m.dialogs.Update(msg)
+//	case tea.KeyMsg:
+//		if m.dialogs.Active() {
+//			m.dialogs, cmd = m.dialogs.Update(msg)
+//			return m, cmd
+//		}
+//	case widgets.DialogResultMsg:
+//		if msg.ID == "quit" && !msg.Canceled { ... }
+//
+//	func (m model) View() string {
+//		return m.dialogs.View(m.content())
+//	}
+type Dialogs struct {
+	// Dim dims the view under the dialog.
+	Dim bool
+	// Shadow is the drop shadow of the dialog.
+	Shadow txui.Shadow
+
+	stack         []Dialog
+	width, height int
+}
+
+// NewDialogs returns an empty manager dimming the view under its dialogs.
+func NewDialogs() Dialogs {
+	return Dialogs{Dim: true, Shadow: txui.ShadowBlock}
+}
+
+// Open shows d on top of the open dialogs.
+func (m *Dialogs) Open(d Dialog) tea.Cmd {
+	d.focus = max(min(d.Default, len(d.Buttons)-1), 0)
+	var cmd tea.Cmd
+	if d.prompt {
+		cmd = d.input.Focus()
+	}
+	m.stack = append(m.stack[:len(m.stack):len(m.stack)], d)
+	return tea.Batch(cmd, d.Init())
+}
+
+// Active reports whether a dialog is open.
+func (m Dialogs) Active() bool {
+	return len(m.stack) > 0
+}
+
+// Top returns the topmost dialog.
+func (m Dialogs) Top() (Dialog, bool) {
+	if len(m.stack) == 0 {
+		return Dialog{}, false
+	}
+	return m.stack[len(m.stack)-1], true
+}
+
+// Close closes the topmost dialog without a result.
+func (m *Dialogs) Close() {
+	if len(m.stack) > 0 {
+		m.stack = append([]Dialog(nil), m.stack[:len(m.stack)-1]...)
+	}
+}
+
+// Update follows the terminal size and passes everything else to the
+// topmost dialog, closing it when it is answered.
+func (m Dialogs) Update(msg tea.Msg) (Dialogs, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width, m.height = size.Width, size.Height
+		return m, nil
+	}
+	if len(m.stack) == 0 {
+		return m, nil
+	}
+	top := len(m.stack) - 1
+	d, cmd, done := m.stack[top].Update(msg)
+	stack := append([]Dialog(nil), m.stack...)
+	if done {
+		stack = stack[:top]
+	} else {
+		stack[top] = d
+	}
+	m.stack = stack
+	return m, cmd
+}
+
+// View draws the topmost dialog centered over background with a
+// ui.Compositor, padding background to the terminal size.
+func (m Dialogs) View(background string) string {
+	d, ok := m.Top()
+	if !ok {
+		return background
+	}
+	layer := txui.NewLayer(d.View()).Center().Shadow(m.Shadow)
+	if m.Dim {
+		layer = layer.DimBelow()
+	}
+	return txui.NewCompositor(m.width, m.height).Profile(d.theme.ColorProfile()).Add(layer).Render(background)
+}
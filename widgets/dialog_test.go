@@ -0,0 +1,122 @@
+package widgets
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+// press sends keys to d and returns the result message, if it closed.
+func press(t *testing.T, d Dialog, keys ...tea.KeyMsg) (Dialog, *DialogResultMsg) {
+	t.Helper()
+	for _, k := range keys {
+		var (
+			cmd  tea.Cmd
+			done bool
+		)
+		d, cmd, done = d.Update(k)
+		if done {
+			msg := cmd().(DialogResultMsg)
+			return d, &msg
+		}
+	}
+	return d, nil
+}
+
+var (
+	keyTab      = tea.KeyMsg{Type: tea.KeyTab}
+	keyShiftTab = tea.KeyMsg{Type: tea.KeyShiftTab}
+	keyEnter    = tea.KeyMsg{Type: tea.KeyEnter}
+	keyEsc      = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func TestDialogResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialog   Dialog
+		keys     []tea.KeyMsg
+		button   int
+		label    string
+		canceled bool
+	}{
+		{"confirm yes", NewConfirmDialog(nil, "q", "Quit", "Sure?"), []tea.KeyMsg{keyEnter}, 0, "Yes", false},
+		{"confirm next", NewConfirmDialog(nil, "q", "Quit", "Sure?"), []tea.KeyMsg{keyTab, keyEnter}, 1, "No", true},
+		{"focus wraps", NewConfirmDialog(nil, "q", "Quit", "Sure?"), []tea.KeyMsg{keyShiftTab, keyTab, keyTab, keyEnter}, 1, "No", true},
+		{"confirm esc", NewConfirmDialog(nil, "q", "Quit", "Sure?"), []tea.KeyMsg{keyEsc}, 1, "No", true},
+		{"alert esc", NewAlertDialog(nil, "a", "Done", "Saved"), []tea.KeyMsg{keyEsc}, 0, "OK", true},
+		{"no buttons", NewDialog(nil, "n", "Note", "Text"), []tea.KeyMsg{keyEnter}, -1, "", false},
+		{"custom esc ignored", NewDialog(nil, "c", "Pick", "", "A", "B"), []tea.KeyMsg{keyEsc, keyTab, keyEnter}, 1, "B", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ds Dialogs
+			ds.Open(tt.dialog)
+			d, _ := ds.Top()
+			_, msg := press(t, d, tt.keys...)
+			if msg == nil {
+				t.Fatal("dialog did not close")
+			}
+			if msg.Button != tt.button || msg.Label != tt.label || msg.Canceled != tt.canceled {
+				t.Errorf("result = %+v, want button %d %q canceled %v", *msg, tt.button, tt.label, tt.canceled)
+			}
+		})
+	}
+}
+
+func TestPromptDialogValue(t *testing.T) {
+	ds := NewDialogs()
+	ds.Open(NewPromptDialog(nil, "name", "Rename", "New name", "board"))
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("todo")}, keyEnter} {
+		var cmd tea.Cmd
+		ds, cmd = ds.Update(k)
+		if cmd == nil || ds.Active() {
+			continue
+		}
+		for _, msg := range collect(cmd) {
+			if r, ok := msg.(DialogResultMsg); ok {
+				if r.Value != "todo" || r.Canceled {
+					t.Errorf("result = %+v, want value %q", r, "todo")
+				}
+				return
+			}
+		}
+	}
+	t.Fatal("prompt did not report its value")
+}
+
+func TestDialogsCenterOnWindow(t *testing.T) {
+	ds := NewDialogs()
+	ds.Dim = false
+	ds.Shadow = txui.ShadowNone
+	ds.Open(NewAlertDialog(nil, "a", "", "Hi"))
+	ds, _ = ds.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	view := ds.View("")
+	lines := strings.Split(view, "\n")
+	if len(lines) != 24 || txui.StringWidth(lines[0]) != 80 {
+		t.Fatalf("view is %d lines, first %d wide; want 80×24", len(lines), txui.StringWidth(lines[0]))
+	}
+	d, _ := ds.Top()
+	box := d.View()
+	top := (24 - strings.Count(box, "\n") - 1) / 2
+	if strings.TrimSpace(lines[top]) == "" || strings.TrimSpace(lines[top-1]) != "" {
+		t.Errorf("dialog not centered: row %d = %q", top, lines[top])
+	}
+}
+
+// collect runs cmd, flattening batches, and returns the messages.
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collect(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
@@ -0,0 +1,367 @@
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+// FieldKind is the type of input of a FormField and of the value it yields.
+type FieldKind int
+
+const (
+	TextField     FieldKind = iota // string
+	PasswordField                  // string, masked
+	NumberField                    // float64
+	DateField                      // time.Time, parsed with DateLayout
+	SelectField                    // string, one of Options
+	CheckboxField                  // bool
+)
+
+// ErrRequired is reported for empty required fields.
+var ErrRequired = errors.New("required")
+
+// FormField describes one input of a Form.
+type FormField struct {
+	Key         string // Key of the value in FormValues.
+	Label       string
+	Kind        FieldKind
+	Required    bool
+	Placeholder string
+	Value       string   // Initial text, option or "true" for checkboxes.
+	Options     []string // Choices of a SelectField.
+	DateLayout  string   // time.Parse layout of a DateField, "2006-01-02" if empty.
+	// Validate checks the typed value of the field once it parses.
+	Validate func(value any) error
+}
+
+// FormValues holds the typed values of a submitted form by field key.
+type FormValues map[string]any
+
+// String returns the value of a text, password or select field.
+func (v FormValues) String(key string) string {
+	s, _ := v[key].(string)
+	return s
+}
+
+// Float returns the value of a number field.
+func (v FormValues) Float(key string) float64 {
+	f, _ := v[key].(float64)
+	return f
+}
+
+// Time returns the value of a date field.
+func (v FormValues) Time(key string) time.Time {
+	t, _ := v[key].(time.Time)
+	return t
+}
+
+// Bool returns the value of a checkbox field.
+func (v FormValues) Bool(key string) bool {
+	b, _ := v[key].(bool)
+	return b
+}
+
+// FormSubmitMsg is sent when a form is submitted with every field valid.
+type FormSubmitMsg struct {
+	Values FormValues
+}
+
+// FormKeyMap holds the key bindings of a Form.
+type FormKeyMap struct {
+	Next, Prev key.Binding
+	// Submit moves to the next field, and submits from the last one.
+	Submit key.Binding
+	Toggle key.Binding
+}
+
+// DefaultFormKeyMap returns the default bindings.
+func DefaultFormKeyMap() FormKeyMap {
+	return FormKeyMap{
+		Next:   key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		Prev:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous")),
+		Submit: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+		Toggle: key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "toggle")),
+	}
+}
+
+// Form composes several fields, each built on ui.InitTI for text input,
+// RadioGroup for choices or a checkbox. Fields are validated when left and
+// on submit, with the error shown under them in the theme's ErrorColor.
+type Form struct {
+	KeyMap FormKeyMap
+
+	theme     *txui.Theme
+	fields    []FormField
+	inputs    []textinput.Model
+	selects   []RadioGroup
+	checks    []bool
+	errs      []error
+	focus     int
+	submitted bool
+}
+
+// NewForm returns a form focused on its first field. A nil theme uses
+// ui.DefaultTheme.
+func NewForm(theme *txui.Theme, fields ...FormField) Form {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	m := Form{
+		KeyMap:  DefaultFormKeyMap(),
+		theme:   theme,
+		fields:  fields,
+		inputs:  make([]textinput.Model, len(fields)),
+		selects: make([]RadioGroup, len(fields)),
+		checks:  make([]bool, len(fields)),
+		errs:    make([]error, len(fields)),
+	}
+	for i, f := range fields {
+		switch f.Kind {
+		case SelectField:
+			rg := NewRadioGroup(theme, f.Options...)
+			rg.Horizontal, rg.SelectOnMove = true, true
+			for j, opt := range f.Options {
+				if opt == f.Value {
+					rg.SetSelected(j)
+				}
+			}
+			rg.Blur()
+			m.selects[i] = rg
+		case CheckboxField:
+			m.checks[i] = f.Value == "true"
+		default:
+			ti := txui.InitTI()
+			ti.Placeholder = f.Placeholder
+			ti.SetValue(f.Value)
+			ti.TextStyle = theme.UserInputStyle
+			ti.PlaceholderStyle = theme.SuggestionStyle
+			if f.Kind == PasswordField {
+				ti.EchoMode = textinput.EchoPassword
+			}
+			if f.Kind == DateField && ti.Placeholder == "" {
+				ti.Placeholder = f.layout()
+			}
+			ti.Blur()
+			m.inputs[i] = ti
+		}
+	}
+	m.setFocus(0)
+	return m
+}
+
+func (f FormField) layout() string {
+	if f.DateLayout == "" {
+		return "2006-01-02"
+	}
+	return f.DateLayout
+}
+
+// Init starts the cursor blinking.
+func (m Form) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Submitted reports whether the form was submitted.
+func (m Form) Submitted() bool {
+	return m.submitted
+}
+
+// Values returns the typed values of the fields that are valid.
+func (m Form) Values() FormValues {
+	values := FormValues{}
+	for i, f := range m.fields {
+		if v, err := m.value(i); err == nil {
+			values[f.Key] = v
+		}
+	}
+	return values
+}
+
+// Err returns the validation error of the field key, if any.
+func (m Form) Err(key string) error {
+	for i, f := range m.fields {
+		if f.Key == key {
+			return m.errs[i]
+		}
+	}
+	return nil
+}
+
+// value parses field i into its typed value and validates it.
+func (m Form) value(i int) (any, error) {
+	f := m.fields[i]
+	var v any
+	switch f.Kind {
+	case CheckboxField:
+		if f.Required && !m.checks[i] {
+			return nil, ErrRequired
+		}
+		v = m.checks[i]
+	case SelectField:
+		s := m.selects[i].Value()
+		if f.Required && s == "" {
+			return nil, ErrRequired
+		}
+		v = s
+	default:
+		s := strings.TrimSpace(m.inputs[i].Value())
+		if s == "" && f.Required {
+			return nil, ErrRequired
+		}
+		if s == "" && (f.Kind == NumberField || f.Kind == DateField) {
+			return nil, nil // Optional and left empty.
+		}
+		switch {
+		case f.Kind == NumberField:
+			n, err := txui.ParseFloat(s)
+			if err != nil {
+				return nil, err
+			}
+			v = n
+		case f.Kind == DateField:
+			d, err := txui.ParseDate(s, f.layout())
+			if err != nil {
+				return nil, err
+			}
+			v = d
+		case f.Kind == PasswordField:
+			v = m.inputs[i].Value()
+		default:
+			v = s
+		}
+	}
+	if f.Validate != nil {
+		if err := f.Validate(v); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (m *Form) validate(i int) bool {
+	_, m.errs[i] = m.value(i)
+	return m.errs[i] == nil
+}
+
+// setFocus moves the focus to field i, wrapping around.
+func (m *Form) setFocus(i int) tea.Cmd {
+	if len(m.fields) == 0 {
+		return nil
+	}
+	m.inputs[m.focus].Blur()
+	m.selects[m.focus].Blur()
+	m.focus = (i + len(m.fields)) % len(m.fields)
+	switch m.fields[m.focus].Kind {
+	case SelectField:
+		m.selects[m.focus].Focus()
+	case CheckboxField:
+	default:
+		return m.inputs[m.focus].Focus()
+	}
+	return nil
+}
+
+// Update moves the focus, edits the focused field and submits.
+func (m Form) Update(msg tea.Msg) (Form, tea.Cmd) {
+	if len(m.fields) == 0 {
+		return m, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// Cursor blinks.
+		var cmd tea.Cmd
+		for i, f := range m.fields {
+			if f.Kind != SelectField && f.Kind != CheckboxField {
+				var c tea.Cmd
+				m.inputs[i], c = m.inputs[i].Update(msg)
+				cmd = tea.Batch(cmd, c)
+			}
+		}
+		return m, cmd
+	}
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.Next):
+		m.validate(m.focus)
+		return m, m.setFocus(m.focus + 1)
+	case key.Matches(keyMsg, m.KeyMap.Prev):
+		m.validate(m.focus)
+		return m, m.setFocus(m.focus - 1)
+	case key.Matches(keyMsg, m.KeyMap.Submit):
+		m.validate(m.focus)
+		if m.focus < len(m.fields)-1 {
+			return m, m.setFocus(m.focus + 1)
+		}
+		return m.submit()
+	}
+	var cmd tea.Cmd
+	switch m.fields[m.focus].Kind {
+	case CheckboxField:
+		if key.Matches(keyMsg, m.KeyMap.Toggle) {
+			m.checks[m.focus] = !m.checks[m.focus]
+		}
+	case SelectField:
+		m.selects[m.focus], cmd = m.selects[m.focus].Update(msg)
+	default:
+		m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	}
+	return m, cmd
+}
+
+// submit validates every field and sends FormSubmitMsg, or focuses the first
+// invalid field.
+func (m Form) submit() (Form, tea.Cmd) {
+	first := -1
+	for i := range m.fields {
+		if !m.validate(i) && first < 0 {
+			first = i
+		}
+	}
+	if first >= 0 {
+		return m, m.setFocus(first)
+	}
+	m.submitted = true
+	values := m.Values()
+	return m, func() tea.Msg { return FormSubmitMsg{Values: values} }
+}
+
+// View renders every field with its label and error.
+func (m Form) View() string {
+	t := m.theme
+	var sb strings.Builder
+	for i, f := range m.fields {
+		cursor := "  "
+		label := f.Label
+		if i == m.focus {
+			cursor = t.CheckboxStyle.Render("> ")
+			label = t.KeywordStyle.Render(label)
+		}
+		sb.WriteString(cursor + label)
+		if f.Required {
+			sb.WriteString(t.ErrorStyle.Render(" *"))
+		}
+		sb.WriteByte('\n')
+		switch f.Kind {
+		case CheckboxField:
+			sb.WriteString("  " + t.Checkbox("", m.checks[i]))
+		case SelectField:
+			sb.WriteString(m.selects[i].View())
+		default:
+			sb.WriteString("  " + m.inputs[i].View())
+		}
+		sb.WriteByte('\n')
+		if m.errs[i] != nil {
+			sb.WriteString("  " + t.ErrorStyle.Render(m.errs[i].Error()) + "\n")
+		}
+	}
+	help := fmt.Sprintf("%s next • %s previous • %s submit",
+		m.KeyMap.Next.Help().Key, m.KeyMap.Prev.Help().Key, m.KeyMap.Submit.Help().Key)
+	sb.WriteString(t.HelpStyle.Render(help))
+	return sb.String()
+}
@@ -0,0 +1,327 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+// Mask restricts what a MaskedInput accepts and turns its text into a typed
+// value.
+type Mask struct {
+	// Pattern holds one slot per character to type: '9' a digit, 'a' a
+	// letter, '*' anything. Other characters are literals inserted on their
+	// own. Empty for free-form masks, which use Accept instead.
+	Pattern string
+	// Placeholder is the ghost text shown for what is left to type.
+	Placeholder string
+	// Accept reports whether r may follow text in a free-form mask.
+	Accept func(text string, r rune) bool
+	// Prefix is shown before the text, like a currency symbol.
+	Prefix string
+	// Parse turns the text into a typed value.
+	Parse func(text string) (any, error)
+}
+
+// The predefined masks.
+var (
+	MaskDate = Mask{Pattern: "9999-99-99", Placeholder: "YYYY-MM-DD", Parse: func(s string) (any, error) {
+		return txui.ParseDate(s, "2006-01-02")
+	}}
+	MaskTime = Mask{Pattern: "99:99", Placeholder: "HH:MM", Parse: func(s string) (any, error) {
+		return txui.ParseClock(s)
+	}}
+	MaskIPv4  = Mask{Placeholder: "0.0.0.0", Accept: acceptIPv4, Parse: func(s string) (any, error) { return txui.ParseIPv4(s) }}
+	MaskPhone = PhoneMask("999 999 999")
+)
+
+// PhoneMask returns a mask for phone numbers following pattern, like
+// "+99 999 999 999". The value is the number's digits as a string.
+func PhoneMask(pattern string) Mask {
+	ghost := strings.Map(func(r rune) rune {
+		if r == '9' {
+			return '_'
+		}
+		return r
+	}, pattern)
+	return Mask{Pattern: pattern, Placeholder: ghost, Parse: func(s string) (any, error) {
+		return txui.ParsePhone(s)
+	}}
+}
+
+// DecimalMask returns a mask for numbers with up to places decimals. The
+// value is a float64.
+func DecimalMask(places int) Mask {
+	return Mask{
+		Placeholder: txui.FormatStringAsFloatWithDecimals("0", places),
+		Accept:      acceptDecimal(places),
+		Parse: func(s string) (any, error) {
+			return txui.ParseDecimal(s, places)
+		},
+	}
+}
+
+// CurrencyMask returns a mask for amounts of money in symbol with up to
+// places decimals. The value is a float64. The mask only takes a decimal
+// separator, so the text is read as a decimal rather than with the thousands
+// guessing of ui.ParseCurrency.
+func CurrencyMask(symbol string, places int) Mask {
+	m := DecimalMask(places)
+	m.Prefix = symbol + " "
+	return m
+}
+
+func acceptDecimal(places int) func(string, rune) bool {
+	return func(text string, r rune) bool {
+		sep := strings.IndexAny(text, ".,")
+		switch {
+		case r == '-':
+			return text == ""
+		case r == '.' || r == ',':
+			return sep < 0 && places > 0
+		case unicode.IsDigit(r):
+			return sep < 0 || len(text)-sep-1 < places
+		}
+		return false
+	}
+}
+
+func acceptIPv4(text string, r rune) bool {
+	octets := strings.Split(text, ".")
+	last := octets[len(octets)-1]
+	switch {
+	case r == '.':
+		return last != "" && len(octets) < 4
+	case unicode.IsDigit(r):
+		return len(last) < 3
+	}
+	return false
+}
+
+// slots returns the positions of Pattern where characters are typed.
+func (m Mask) slots() []int {
+	var slots []int
+	for i, r := range []rune(m.Pattern) {
+		if r == '9' || r == 'a' || r == '*' {
+			slots = append(slots, i)
+		}
+	}
+	return slots
+}
+
+// MaskedInput is a text input restricted by a Mask: keystrokes the mask
+// does not accept are dropped and the rest of the expected text shows as a
+// ghost in the theme's SuggestionStyle. The cursor moves with the arrows,
+// Home and End; typing and deleting happen at the cursor.
+type MaskedInput struct {
+	Mask   Mask
+	Prompt string
+
+	theme   *txui.Theme
+	typed   []rune // Typed characters, without the pattern's literals.
+	pos     int    // Cursor, as an index of typed.
+	focused bool
+}
+
+// NewMaskedInput returns a focused, empty input. A nil theme uses
+// ui.DefaultTheme.
+func NewMaskedInput(theme *txui.Theme, mask Mask) MaskedInput {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return MaskedInput{Mask: mask, theme: theme, focused: true}
+}
+
+// Init implements tea.Model.
+func (m MaskedInput) Init() tea.Cmd {
+	return nil
+}
+
+// Focus makes the input react to keys.
+func (m *MaskedInput) Focus() {
+	m.focused = true
+}
+
+// Blur makes the input ignore keys.
+func (m *MaskedInput) Blur() {
+	m.focused = false
+}
+
+// Focused reports whether the input reacts to keys.
+func (m MaskedInput) Focused() bool {
+	return m.focused
+}
+
+// SetValue replaces the text, keeping only what the mask accepts.
+func (m *MaskedInput) SetValue(s string) {
+	m.typed, m.pos = nil, 0
+	for _, r := range s {
+		m.insert(r)
+	}
+}
+
+// Value returns the text as shown, literals included.
+func (m MaskedInput) Value() string {
+	if m.Mask.Pattern == "" {
+		return string(m.typed)
+	}
+	pattern := []rune(m.Mask.Pattern)
+	var sb strings.Builder
+	n := 0
+	for _, r := range pattern {
+		if n == len(m.typed) {
+			break
+		}
+		if r == '9' || r == 'a' || r == '*' {
+			sb.WriteRune(m.typed[n])
+			n++
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// Complete reports whether every slot of a pattern mask is filled.
+func (m MaskedInput) Complete() bool {
+	return m.Mask.Pattern == "" || len(m.typed) == len(m.Mask.slots())
+}
+
+// Typed parses the text with the mask, reporting incomplete input.
+func (m MaskedInput) Typed() (any, error) {
+	if !m.Complete() {
+		return nil, fmt.Errorf("incomplete, expected %s", m.Mask.Placeholder)
+	}
+	if m.Mask.Parse == nil {
+		return m.Value(), nil
+	}
+	v, err := m.Mask.Parse(m.Value())
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// accepts reports whether the mask takes typed as a whole.
+func (m MaskedInput) accepts(typed []rune) bool {
+	if m.Mask.Pattern == "" {
+		for i, r := range typed {
+			if m.Mask.Accept != nil && !m.Mask.Accept(string(typed[:i]), r) {
+				return false
+			}
+		}
+		return true
+	}
+	slots := m.Mask.slots()
+	if len(typed) > len(slots) {
+		return false
+	}
+	pattern := []rune(m.Mask.Pattern)
+	for i, r := range typed {
+		switch pattern[slots[i]] {
+		case '9':
+			if !unicode.IsDigit(r) {
+				return false
+			}
+		case 'a':
+			if !unicode.IsLetter(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// edit replaces the typed characters [from, to) with rs if the mask accepts
+// the result, leaving the cursor after rs.
+func (m *MaskedInput) edit(from, to int, rs ...rune) {
+	typed := make([]rune, 0, len(m.typed)-(to-from)+len(rs))
+	typed = append(typed, m.typed[:from]...)
+	typed = append(typed, rs...)
+	typed = append(typed, m.typed[to:]...)
+	if m.accepts(typed) {
+		m.typed, m.pos = typed, from+len(rs)
+	}
+}
+
+// insert adds r at the cursor if the mask accepts it.
+func (m *MaskedInput) insert(r rune) {
+	m.edit(m.pos, m.pos, r)
+}
+
+// Update edits the text while the input is focused.
+func (m MaskedInput) Update(msg tea.Msg) (MaskedInput, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focused {
+		return m, nil
+	}
+	m.pos = min(max(m.pos, 0), len(m.typed))
+	switch keyMsg.Type {
+	case tea.KeyRunes, tea.KeySpace:
+		for _, r := range keyMsg.Runes {
+			m.insert(r)
+		}
+	case tea.KeyBackspace:
+		if m.pos > 0 {
+			m.edit(m.pos-1, m.pos)
+		}
+	case tea.KeyDelete:
+		if m.pos < len(m.typed) {
+			pos := m.pos
+			m.edit(pos, pos+1)
+			m.pos = pos
+		}
+	case tea.KeyLeft:
+		m.pos = max(m.pos-1, 0)
+	case tea.KeyRight:
+		m.pos = min(m.pos+1, len(m.typed))
+	case tea.KeyHome:
+		m.pos = 0
+	case tea.KeyEnd:
+		m.pos = len(m.typed)
+	case tea.KeyCtrlU:
+		m.typed, m.pos = nil, 0
+	}
+	return m, nil
+}
+
+// cursorIndex returns the rune of Value the cursor is on.
+func (m MaskedInput) cursorIndex() int {
+	if m.Mask.Pattern == "" || m.pos >= len(m.typed) {
+		return min(m.pos, len(m.typed))
+	}
+	return m.Mask.slots()[m.pos]
+}
+
+// View renders the prompt, the text and the ghost of what is left to type.
+func (m MaskedInput) View() string {
+	t := m.theme
+	text := m.Value()
+	var ghost string
+	switch {
+	case m.Mask.Pattern != "":
+		ghost = txui.SliceColumns(m.Mask.Placeholder, txui.StringWidth(text), txui.StringWidth(m.Mask.Placeholder))
+	case text == "":
+		ghost = m.Mask.Placeholder
+	}
+	if !m.focused {
+		return m.Prompt + m.Mask.Prefix + t.UserInputStyle.Render(text) + t.SuggestionStyle.Render(ghost)
+	}
+	if runes := []rune(text); m.pos < len(m.typed) {
+		// The cursor is on a typed character.
+		i := m.cursorIndex()
+		before, at, after := string(runes[:i]), string(runes[i]), string(runes[i+1:])
+		return m.Prompt + m.Mask.Prefix + t.UserInputStyle.Render(before) + t.UserInputStyle.Reverse(true).Render(at) +
+			t.UserInputStyle.Render(after) + t.SuggestionStyle.Render(ghost)
+	}
+	next := " "
+	if ghost != "" {
+		next = txui.SliceColumns(ghost, 0, 1)
+		ghost = txui.SliceColumns(ghost, 1, txui.StringWidth(ghost))
+	}
+	cursor := t.SuggestionStyle.Reverse(true).Render(next)
+	return m.Prompt + m.Mask.Prefix + t.UserInputStyle.Render(text) + cursor + t.SuggestionStyle.Render(ghost)
+}
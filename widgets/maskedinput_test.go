@@ -0,0 +1,58 @@
+package widgets
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeKeys sends keys to m: strings are typed, tea.KeyTypes pressed.
+func typeKeys(m MaskedInput, keys ...any) MaskedInput {
+	for _, k := range keys {
+		switch k := k.(type) {
+		case string:
+			for _, r := range k {
+				m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+		case tea.KeyType:
+			m, _ = m.Update(tea.KeyMsg{Type: k})
+		}
+	}
+	return m
+}
+
+func TestMaskedInputEditing(t *testing.T) {
+	tests := []struct {
+		name string
+		mask Mask
+		keys []any
+		want string
+	}{
+		{"pattern", MaskDate, []any{"20241301"}, "2024-13-01"},
+		{"rejects letters", MaskDate, []any{"2024ab"}, "2024"},
+		{"fix a typo in the middle", MaskDate, []any{"20241301", tea.KeyLeft, tea.KeyLeft, tea.KeyLeft, tea.KeyBackspace, "0"}, "2024-03-01"},
+		{"delete under the cursor", MaskDate, []any{"20241301", tea.KeyHome, tea.KeyRight, tea.KeyRight, tea.KeyRight, tea.KeyRight, tea.KeyDelete, "0"}, "2024-03-01"},
+		{"full pattern takes no more", MaskDate, []any{"20240301", tea.KeyHome, "9"}, "2024-03-01"},
+		{"free form at the start", DecimalMask(2), []any{"12.5", tea.KeyHome, "-"}, "-12.5"},
+		{"free form checks the whole text", DecimalMask(2), []any{"1.25", tea.KeyHome, "."}, "1.25"},
+		{"end", DecimalMask(2), []any{"12", tea.KeyHome, tea.KeyEnd, ".5"}, "12.5"},
+		{"left stops at the start", MaskTime, []any{tea.KeyLeft, "0930"}, "09:30"},
+		{"clear", MaskTime, []any{"09", tea.KeyLeft, tea.KeyCtrlU, "1"}, "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typeKeys(NewMaskedInput(nil, tt.mask), tt.keys...)
+			if got := m.Value(); got != tt.want {
+				t.Errorf("Value = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyMaskDecimals(t *testing.T) {
+	m := typeKeys(NewMaskedInput(nil, CurrencyMask("$", 3)), "1,234")
+	v, err := m.Typed()
+	if err != nil || v != 1.234 {
+		t.Errorf("Typed = %v, %v, want 1.234", v, err)
+	}
+}
@@ -0,0 +1,238 @@
+package widgets
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	txui "txeo-tui-library/ui"
+)
+
+const (
+	// progressSmoothing is the time constant of the animated transitions:
+	// the bar covers ~63% of the remaining distance every progressSmoothing.
+	progressSmoothing = 100 * time.Millisecond
+	// indeterminatePeriod is how long the indeterminate block takes to cross
+	// the bar.
+	indeterminatePeriod = 1500 * time.Millisecond
+	frameInterval       = time.Second / txui.FrameRate
+)
+
+// ProgressBar is a bubbletea component showing how much of a task is done.
+// Changes of percentage are animated on the ui.Frame 60fps tick: forward
+// every message to Update and run the commands it returns.
+//
+//	bar := widgets.NewProgressBar(theme)
+//	bar.Label = "Downloading"
+//	bar, cmd := bar.SetPercent(0.4)
+//
+// The type takes the name of the former ProgressBar(percent) function;
+// NewProgressBar(nil).ViewAs(percent) draws the same bar.
+type ProgressBar struct {
+	// Width of the bar itself, label and percentage excluded. Zero fills the
+	// width of the last tea.WindowSizeMsg, or ProgressBarWidth before any.
+	Width int
+	// Gradient holds the "#hex" stops the filled cells blend through. Empty
+	// uses the theme's ramp.
+	Gradient []string
+	// Full and Empty are the characters of filled and empty cells.
+	Full, Empty string
+	// Label is shown before the bar.
+	Label string
+	// ShowPercent adds the percentage after the bar, formatted with
+	// PercentFormat from a 0-100 float.
+	ShowPercent   bool
+	PercentFormat string
+	// Indeterminate shows a block bouncing across the bar instead of a
+	// percentage, for tasks of unknown length.
+	Indeterminate bool
+
+	theme          *txui.Theme
+	target, shown  float64
+	containerWidth int
+	phase          float64 // Position of the indeterminate block, 0-2.
+	animating      bool
+	last           time.Time
+	ramp           *rampCache // Shared by copies; only a cache.
+}
+
+// rampCache keeps the ramp styles of the last width drawn, so frames do not
+// blend the gradient again.
+type rampCache struct {
+	width  int
+	stops  string
+	styles []lipgloss.Style
+}
+
+// NewProgressBar returns an empty bar showing its percentage. A nil theme
+// uses ui.DefaultTheme.
+func NewProgressBar(theme *txui.Theme) ProgressBar {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return ProgressBar{
+		Full:          ProgressFullChar,
+		Empty:         ProgressEmptyChar,
+		ShowPercent:   true,
+		PercentFormat: "%3.0f%%",
+		theme:         theme,
+		ramp:          &rampCache{},
+	}
+}
+
+// Init starts the animation of indeterminate bars.
+func (m ProgressBar) Init() tea.Cmd {
+	if m.Indeterminate {
+		return m.animate()
+	}
+	return nil
+}
+
+// Percent returns the percentage the bar is heading to, between 0 and 1.
+func (m ProgressBar) Percent() float64 {
+	return m.target
+}
+
+// SetPercent moves the bar to p, clamped between 0 and 1.
+func (m ProgressBar) SetPercent(p float64) (ProgressBar, tea.Cmd) {
+	m.target = clampPercent(p)
+	if m.target == m.shown {
+		return m, nil
+	}
+	return m, m.animate()
+}
+
+// IncrPercent moves the bar by delta.
+func (m ProgressBar) IncrPercent(delta float64) (ProgressBar, tea.Cmd) {
+	return m.SetPercent(m.target + delta)
+}
+
+// SetIndeterminate switches the indeterminate mode on or off.
+func (m ProgressBar) SetIndeterminate(on bool) (ProgressBar, tea.Cmd) {
+	m.Indeterminate = on
+	if on {
+		return m, m.animate()
+	}
+	return m, nil
+}
+
+// animate starts the frame loop unless it is already running.
+func (m *ProgressBar) animate() tea.Cmd {
+	if m.animating {
+		return nil
+	}
+	m.animating = true
+	m.last = time.Now()
+	return txui.Frame()
+}
+
+// Update follows the container width and advances the animation.
+func (m ProgressBar) Update(msg tea.Msg) (ProgressBar, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.containerWidth = msg.Width
+	case txui.FrameMsg:
+		if !m.animating {
+			return m, nil
+		}
+		// Every frame loop running in the program ends up here; skip the
+		// ones arriving right after the frame just handled.
+		now := time.Now()
+		dt := now.Sub(m.last)
+		if dt < frameInterval/2 {
+			return m, nil
+		}
+		m.last = now
+		if m.Indeterminate {
+			m.phase = math.Mod(m.phase+2*dt.Seconds()/indeterminatePeriod.Seconds(), 2)
+		}
+		m.shown += (m.target - m.shown) * (1 - math.Exp(-dt.Seconds()/progressSmoothing.Seconds()))
+		if math.Abs(m.target-m.shown) < 0.0005 {
+			m.shown = m.target
+		}
+		if m.shown == m.target && !m.Indeterminate {
+			m.animating = false
+			return m, nil
+		}
+		return m, txui.Frame()
+	}
+	return m, nil
+}
+
+// View renders the bar at its current point of the animation.
+func (m ProgressBar) View() string {
+	return m.render(m.shown)
+}
+
+// ViewAs renders the bar at percent, ignoring the animation. It is the
+// stateless way of drawing a bar.
+func (m ProgressBar) ViewAs(percent float64) string {
+	return m.render(clampPercent(percent))
+}
+
+func (m ProgressBar) render(percent float64) string {
+	var label, pct string
+	if m.Label != "" {
+		label = m.Label + " "
+	}
+	if m.ShowPercent && !m.Indeterminate {
+		pct = " " + fmt.Sprintf(m.PercentFormat, percent*100)
+	}
+	width := m.Width
+	if width <= 0 {
+		width = ProgressBarWidth
+		if m.containerWidth > 0 {
+			width = m.containerWidth - txui.StringWidth(label) - txui.StringWidth(pct)
+		}
+	}
+	width = max(width, 1)
+
+	from, to := 0, int(math.Round(float64(width)*percent))
+	if m.Indeterminate {
+		size := max(width/4, 1)
+		pos := m.phase
+		if pos > 1 {
+			pos = 2 - pos
+		}
+		from = int(math.Round(pos * float64(width-size)))
+		to = from + size
+	}
+	ramp := m.rampStyles(width)
+	empty := m.theme.SubtleStyle.Render(m.Empty)
+
+	var sb strings.Builder
+	sb.WriteString(label)
+	for i := 0; i < width; i++ {
+		if i >= from && i < to {
+			sb.WriteString(ramp[i].Render(m.Full))
+		} else {
+			sb.WriteString(empty)
+		}
+	}
+	sb.WriteString(pct)
+	return sb.String()
+}
+
+// rampStyles returns the styles of the filled cells of a bar width cells
+// wide.
+func (m ProgressBar) rampStyles(width int) []lipgloss.Style {
+	stops := strings.Join(m.Gradient, ",")
+	if c := m.ramp; c != nil && c.width == width && c.stops == stops && c.styles != nil {
+		return c.styles
+	}
+	styles := m.theme.RampStyles(width, m.Gradient...)
+	if m.ramp != nil {
+		*m.ramp = rampCache{width: width, stops: stops, styles: styles}
+	}
+	return styles
+}
+
+func clampPercent(p float64) float64 {
+	if math.IsNaN(p) || p < 0 {
+		return 0
+	}
+	return min(p, 1)
+}
@@ -0,0 +1,32 @@
+package widgets
+
+import (
+	"testing"
+
+	txui "txeo-tui-library/ui"
+)
+
+func TestProgressbarShim(t *testing.T) {
+	for _, p := range []float64{0, 0.4, 1, 2} {
+		if got, want := txui.Progressbar(p), NewProgressBar(nil).ViewAs(p); got != want {
+			t.Errorf("ui.Progressbar(%v) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestProgressBarRampCache(t *testing.T) {
+	bar := NewProgressBar(nil)
+	bar.Width = 10
+	first := bar.ViewAs(0.5)
+	if bar.ramp.width != 10 || len(bar.ramp.styles) != 10 {
+		t.Fatalf("ramp not cached: %+v", bar.ramp)
+	}
+	if got := bar.ViewAs(0.5); got != first {
+		t.Errorf("cached render %q differs from %q", got, first)
+	}
+	bar.Gradient = []string{"#000000", "#FFFFFF"}
+	bar.ViewAs(0.5)
+	if bar.ramp.stops != "#000000,#FFFFFF" {
+		t.Errorf("cache keyed on %q", bar.ramp.stops)
+	}
+}
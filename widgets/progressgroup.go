@@ -0,0 +1,357 @@
+package widgets
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+const (
+	// rateSmoothing weights the latest sample in the throughput average.
+	rateSmoothing = 0.3
+	// rateStall is how long a task may go without reporting before its
+	// throughput starts to decay, with time constant rateDecay.
+	rateStall = time.Second
+	rateDecay = 2 * time.Second
+)
+
+// TaskProgressMsg reports the progress of one task of a ProgressGroup.
+// Goroutines deliver it with tea.Program.Send, which is safe for concurrent
+// use; TaskTracker does it for them.
+type TaskProgressMsg struct {
+	Task    string
+	Current int64
+	Total   int64 // Zero when unknown, which shows an indeterminate bar.
+	Done    bool
+	Err     error // Marks the task as failed.
+	At      time.Time
+}
+
+// TaskTracker reports the progress of one task from any goroutine. It is an
+// io.Writer counting the bytes written, so it can sit behind io.Copy or an
+// io.TeeReader.
+//
+//	t := widgets.NewTaskTracker(program.Send, "video.mp4", resp.ContentLength)
+//	_, err := io.Copy(file, io.TeeReader(resp.Body, t))
+//	t.Finish(err)
+type TaskTracker struct {
+	send    func(tea.Msg)
+	name    string
+	current atomic.Int64
+	total   atomic.Int64
+}
+
+// NewTaskTracker registers the task name with total units of work and
+// reports through send, usually tea.Program.Send.
+func NewTaskTracker(send func(tea.Msg), name string, total int64) *TaskTracker {
+	t := &TaskTracker{send: send, name: name}
+	t.total.Store(total)
+	t.report(false, nil)
+	return t
+}
+
+func (t *TaskTracker) report(done bool, err error) {
+	t.send(TaskProgressMsg{
+		Task:    t.name,
+		Current: t.current.Load(),
+		Total:   t.total.Load(),
+		Done:    done,
+		Err:     err,
+		At:      time.Now(),
+	})
+}
+
+// Add records n more units of work done.
+func (t *TaskTracker) Add(n int64) {
+	t.current.Add(n)
+	t.report(false, nil)
+}
+
+// Set records the units of work done so far.
+func (t *TaskTracker) Set(n int64) {
+	t.current.Store(n)
+	t.report(false, nil)
+}
+
+// SetTotal changes the units of work of the task.
+func (t *TaskTracker) SetTotal(n int64) {
+	t.total.Store(n)
+	t.report(false, nil)
+}
+
+// Write counts len(p) units of work done.
+func (t *TaskTracker) Write(p []byte) (int, error) {
+	t.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// Finish marks the task as done, or failed when err is not nil.
+func (t *TaskTracker) Finish(err error) {
+	t.report(err == nil, err)
+}
+
+// ProgressGroup shows one ProgressBar per task, with its throughput and
+// estimated time left. Tasks appear with their first TaskProgressMsg, in
+// that order. The throughput of a task that stops reporting decays on the
+// frames and on ui.TickMsg, so keep ui.Tick running and forward its
+// messages for stalled tasks to show it.
+type ProgressGroup struct {
+	// Bar is the template of the bars of new tasks.
+	Bar ProgressBar
+	// NameWidth caps the column of task names.
+	NameWidth int
+	// CollapseDone folds finished tasks into a single summary line.
+	CollapseDone bool
+	// RateFormat renders a throughput in units per second.
+	RateFormat func(perSecond float64) string
+
+	theme     *txui.Theme
+	tasks     []groupTask
+	index     map[string]int
+	width     int
+	animating bool      // The group's frame loop is running.
+	last      time.Time // Last frame handled.
+}
+
+type groupTask struct {
+	name           string
+	bar            ProgressBar
+	current, total int64
+	last           time.Time // Last progress reported.
+	rate           float64   // Units per second, smoothed.
+	decayed        time.Time // Last time rate was sampled or decayed.
+	done           bool
+	err            error
+}
+
+// NewProgressGroup returns an empty group collapsing finished tasks. A nil
+// theme uses ui.DefaultTheme.
+func NewProgressGroup(theme *txui.Theme) ProgressGroup {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return ProgressGroup{
+		Bar:          NewProgressBar(theme),
+		NameWidth:    20,
+		CollapseDone: true,
+		RateFormat:   UnitRate,
+		theme:        theme,
+	}
+}
+
+// Init implements tea.Model.
+func (m ProgressGroup) Init() tea.Cmd {
+	return nil
+}
+
+// Len returns the number of tasks, finished ones included.
+func (m ProgressGroup) Len() int {
+	return len(m.tasks)
+}
+
+// Finished reports whether every task is done or failed.
+func (m ProgressGroup) Finished() bool {
+	for _, t := range m.tasks {
+		if !t.done && t.err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Update records task progress and animates the bars.
+func (m ProgressGroup) Update(msg tea.Msg) (ProgressGroup, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case TaskProgressMsg:
+		return m.record(msg)
+	case txui.TickMsg:
+		m.decay(time.Now())
+	case txui.FrameMsg:
+		return m.frame(msg)
+	}
+	return m, nil
+}
+
+// frame advances every bar once on the group's single frame loop, ignoring
+// frames of other loops arriving right after the one just handled.
+func (m ProgressGroup) frame(msg txui.FrameMsg) (ProgressGroup, tea.Cmd) {
+	now := time.Now()
+	if !m.animating || now.Sub(m.last) < frameInterval/2 {
+		return m, nil
+	}
+	m.last = now
+	m.decay(now)
+	tasks := append([]groupTask(nil), m.tasks...)
+	m.animating = false
+	for i := range tasks {
+		// The bars' own loops are left unscheduled: the group drives them.
+		tasks[i].bar, _ = tasks[i].bar.Update(msg)
+		m.animating = m.animating || tasks[i].bar.animating
+	}
+	m.tasks = tasks
+	if !m.animating {
+		return m, nil
+	}
+	return m, txui.Frame()
+}
+
+// decay lowers the throughput of the running tasks silent for rateStall.
+func (m *ProgressGroup) decay(now time.Time) {
+	var tasks []groupTask
+	for i, t := range m.tasks {
+		if t.done || t.err != nil || t.rate == 0 || now.Sub(t.last) < rateStall {
+			continue
+		}
+		if tasks == nil {
+			// Copy before writing, the caller's model shares the old ones.
+			tasks = append([]groupTask(nil), m.tasks...)
+		}
+		dt := now.Sub(t.decayed).Seconds()
+		tasks[i].rate *= math.Exp(-dt / rateDecay.Seconds())
+		tasks[i].decayed = now
+	}
+	if tasks != nil {
+		m.tasks = tasks
+	}
+}
+
+// animate starts the group's frame loop unless it is already running.
+func (m *ProgressGroup) animate() tea.Cmd {
+	if m.animating {
+		return nil
+	}
+	m.animating = true
+	m.last = time.Now()
+	return txui.Frame()
+}
+
+func (m ProgressGroup) record(msg TaskProgressMsg) (ProgressGroup, tea.Cmd) {
+	// Copy before writing, the caller's model shares the old ones.
+	m.tasks = append([]groupTask(nil), m.tasks...)
+	i, ok := m.index[msg.Task]
+	if !ok {
+		index := make(map[string]int, len(m.index)+1)
+		for name, j := range m.index {
+			index[name] = j
+		}
+		i = len(m.tasks)
+		index[msg.Task] = i
+		m.index = index
+		m.tasks = append(m.tasks, groupTask{name: msg.Task, bar: m.Bar, last: msg.At})
+	}
+	t := &m.tasks[i]
+	if dt := msg.At.Sub(t.last).Seconds(); dt > 0 {
+		sample := float64(msg.Current-t.current) / dt
+		if t.rate == 0 {
+			t.rate = sample
+		} else {
+			t.rate = rateSmoothing*sample + (1-rateSmoothing)*t.rate
+		}
+		t.last, t.decayed = msg.At, msg.At
+	}
+	t.current, t.total = msg.Current, msg.Total
+	t.done, t.err = msg.Done, msg.Err
+
+	percent := 0.0
+	if t.total > 0 {
+		percent = float64(t.current) / float64(t.total)
+	}
+	if t.done {
+		percent = 1
+	}
+	t.bar, _ = t.bar.SetIndeterminate(t.total <= 0 && !t.done && t.err == nil)
+	t.bar, _ = t.bar.SetPercent(percent)
+	if t.bar.animating {
+		return m, m.animate()
+	}
+	return m, nil
+}
+
+// View renders the unfinished tasks, failed ones and either the finished
+// ones or a line counting them.
+func (m ProgressGroup) View() string {
+	nameWidth := 0
+	for _, t := range m.tasks {
+		nameWidth = max(nameWidth, txui.StringWidth(t.name))
+	}
+	nameWidth = min(nameWidth, m.NameWidth)
+
+	var lines []string
+	collapsed := 0
+	for _, t := range m.tasks {
+		if t.done && m.CollapseDone {
+			collapsed++
+			continue
+		}
+		stats := m.stats(t)
+		bar := t.bar
+		bar.Label = txui.PadRight(txui.Truncate(t.name, nameWidth, "…"), nameWidth)
+		if m.width > 0 && bar.Width <= 0 {
+			bar.Width = 1
+			overhead := txui.StringWidth(bar.View()) - 1
+			bar.Width = m.width - overhead - txui.StringWidth(stats)
+		}
+		lines = append(lines, bar.View()+stats)
+	}
+	if collapsed > 0 {
+		lines = append(lines, m.theme.CheckMark()+m.theme.SubtleStyle.Render(fmt.Sprintf("%d done", collapsed)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stats renders the throughput and time left of t.
+func (m ProgressGroup) stats(t groupTask) string {
+	switch {
+	case t.err != nil:
+		return " " + m.theme.ErrorStyle.Render(t.err.Error())
+	case t.done:
+		return " " + m.theme.CheckMark()
+	}
+	eta := "--:--"
+	if t.total > 0 && t.rate > 0 {
+		eta = formatETA(time.Duration(float64(t.total-t.current) / t.rate * float64(time.Second)))
+	}
+	return fmt.Sprintf(" %10s  ETA %s", m.RateFormat(t.rate), eta)
+}
+
+// formatETA renders d as m:ss, or h:mm:ss past an hour.
+func formatETA(d time.Duration) string {
+	s := int(math.Round(d.Seconds()))
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// UnitRate renders a throughput with SI prefixes, like "1.5k/s".
+func UnitRate(perSecond float64) string {
+	return siPrefix(perSecond, 1000, "") + "/s"
+}
+
+// ByteRate renders a throughput in bytes, like "1.5 MiB/s".
+func ByteRate(perSecond float64) string {
+	return siPrefix(perSecond, 1024, " ") + "B/s"
+}
+
+func siPrefix(v, base float64, sep string) string {
+	prefixes := []string{"", "k", "M", "G", "T"}
+	if base == 1024 {
+		prefixes = []string{"", "Ki", "Mi", "Gi", "Ti"}
+	}
+	i := 0
+	for v >= base && i < len(prefixes)-1 {
+		v /= base
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f%s", v, sep)
+	}
+	return fmt.Sprintf("%.1f%s%s", v, sep, prefixes[i])
+}
@@ -0,0 +1,49 @@
+package widgets
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	txui "txeo-tui-library/ui"
+)
+
+func TestProgressGroupStalledRateDecays(t *testing.T) {
+	g := NewProgressGroup(nil)
+	start := time.Now().Add(-10 * time.Second)
+	g, _ = g.Update(TaskProgressMsg{Task: "a", Current: 0, Total: 1000, At: start})
+	g, _ = g.Update(TaskProgressMsg{Task: "a", Current: 100, Total: 1000, At: start.Add(time.Second)})
+	rate := g.tasks[0].rate
+	if rate != 100 {
+		t.Fatalf("rate = %v, want 100", rate)
+	}
+	before := g
+	g, _ = g.Update(txui.TickMsg{})
+	if got := g.tasks[0].rate; got >= rate/10 {
+		t.Errorf("rate after 9s of silence = %v, want it well below %v", got, rate)
+	}
+	if before.tasks[0].rate != rate {
+		t.Error("decay changed the previous model")
+	}
+}
+
+func TestProgressGroupActiveRateKept(t *testing.T) {
+	g := NewProgressGroup(nil)
+	now := time.Now()
+	g, _ = g.Update(TaskProgressMsg{Task: "a", Current: 0, Total: 1000, At: now.Add(-time.Second)})
+	g, _ = g.Update(TaskProgressMsg{Task: "a", Current: 50, Total: 1000, At: now})
+	g, _ = g.Update(TaskProgressMsg{Task: "b", Total: 10, Err: errors.New("boom"), At: now})
+	g, _ = g.Update(txui.TickMsg{})
+	if got := g.tasks[0].rate; got != 50 {
+		t.Errorf("rate of a reporting task = %v, want 50", got)
+	}
+}
+
+func TestProgressGroupZeroValue(t *testing.T) {
+	var g ProgressGroup
+	g.Bar = NewProgressBar(nil)
+	g, _ = g.Update(TaskProgressMsg{Task: "a", Total: 10, At: time.Now()})
+	if g.Len() != 1 {
+		t.Errorf("Len = %d, want 1", g.Len())
+	}
+}
@@ -0,0 +1,148 @@
+package widgets
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+// RadioGroupKeyMap holds the key bindings of a RadioGroup.
+type RadioGroupKeyMap struct {
+	Prev, Next key.Binding
+	Select     key.Binding
+}
+
+// DefaultRadioGroupKeyMap returns the default bindings, arrows and vim keys
+// in both directions so they work for vertical and horizontal groups.
+func DefaultRadioGroupKeyMap() RadioGroupKeyMap {
+	return RadioGroupKeyMap{
+		Prev:   key.NewBinding(key.WithKeys("up", "left", "k", "h"), key.WithHelp("←/↑", "previous")),
+		Next:   key.NewBinding(key.WithKeys("down", "right", "j", "l"), key.WithHelp("→/↓", "next")),
+		Select: key.NewBinding(key.WithKeys(" ", "enter"), key.WithHelp("space", "select")),
+	}
+}
+
+// RadioGroup is a single-choice input.
+type RadioGroup struct {
+	KeyMap  RadioGroupKeyMap
+	Options []string
+	// Horizontal lays the options out on one line.
+	Horizontal bool
+	// On and Off are the glyphs of the selected and the other options.
+	On, Off string
+	// SelectOnMove selects the option under the cursor as it moves, so no
+	// Select key is needed.
+	SelectOnMove bool
+
+	theme    *txui.Theme
+	cursor   int
+	selected int
+	focused  bool
+}
+
+// NewRadioGroup returns a focused vertical group with nothing selected. A
+// nil theme uses ui.DefaultTheme.
+func NewRadioGroup(theme *txui.Theme, options ...string) RadioGroup {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return RadioGroup{
+		KeyMap:   DefaultRadioGroupKeyMap(),
+		Options:  options,
+		On:       "(•)",
+		Off:      "( )",
+		theme:    theme,
+		selected: -1,
+		focused:  true,
+	}
+}
+
+// Init implements tea.Model.
+func (m RadioGroup) Init() tea.Cmd {
+	return nil
+}
+
+// Selected returns the index of the selected option, -1 when none is.
+func (m RadioGroup) Selected() int {
+	return m.selected
+}
+
+// Value returns the selected option, "" when none is.
+func (m RadioGroup) Value() string {
+	if m.selected < 0 || m.selected >= len(m.Options) {
+		return ""
+	}
+	return m.Options[m.selected]
+}
+
+// SetSelected selects option i and moves the cursor to it; -1 clears the
+// selection.
+func (m *RadioGroup) SetSelected(i int) {
+	if i < -1 || i >= len(m.Options) {
+		return
+	}
+	m.selected = i
+	if i >= 0 {
+		m.cursor = i
+	}
+}
+
+// Focus makes the group react to keys.
+func (m *RadioGroup) Focus() {
+	m.focused = true
+}
+
+// Blur makes the group ignore keys.
+func (m *RadioGroup) Blur() {
+	m.focused = false
+}
+
+// Focused reports whether the group reacts to keys.
+func (m RadioGroup) Focused() bool {
+	return m.focused
+}
+
+// Update handles the keys while the group is focused.
+func (m RadioGroup) Update(msg tea.Msg) (RadioGroup, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focused {
+		return m, nil
+	}
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.Prev):
+		m.cursor = max(m.cursor-1, 0)
+		if m.SelectOnMove {
+			m.SetSelected(m.cursor)
+		}
+	case key.Matches(keyMsg, m.KeyMap.Next):
+		m.cursor = min(m.cursor+1, len(m.Options)-1)
+		if m.SelectOnMove {
+			m.SetSelected(m.cursor)
+		}
+	case key.Matches(keyMsg, m.KeyMap.Select):
+		m.SetSelected(m.cursor)
+	}
+	return m, nil
+}
+
+// View renders the options, the selected one in the theme's CheckboxStyle.
+func (m RadioGroup) View() string {
+	items := make([]string, len(m.Options))
+	for i, opt := range m.Options {
+		cursor := "  "
+		if i == m.cursor && m.focused {
+			cursor = m.theme.CheckboxStyle.Render("> ")
+		}
+		if i == m.selected {
+			items[i] = cursor + m.theme.CheckboxStyle.Render(m.On+" "+opt)
+		} else {
+			items[i] = cursor + m.Off + " " + opt
+		}
+	}
+	if m.Horizontal {
+		return strings.Join(items, " ")
+	}
+	return strings.Join(items, "\n")
+}
@@ -0,0 +1,115 @@
+package widgets
+
+import (
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	txui "txeo-tui-library/ui"
+)
+
+// SpinnerFrames is a spinner animation: its frames and how many of them
+// play per second.
+type SpinnerFrames struct {
+	Frames []string
+	FPS    int
+}
+
+// The spinner catalogue.
+var (
+	SpinnerDots        = SpinnerFrames{Frames: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}, FPS: 12}
+	SpinnerLine        = SpinnerFrames{Frames: []string{"|", "/", "-", "\\"}, FPS: 10}
+	SpinnerBraille     = SpinnerFrames{Frames: []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"}, FPS: 12}
+	SpinnerMoon        = SpinnerFrames{Frames: []string{"🌑", "🌒", "🌓", "🌔", "🌕", "🌖", "🌗", "🌘"}, FPS: 8}
+	SpinnerClock       = SpinnerFrames{Frames: []string{"🕛", "🕐", "🕑", "🕒", "🕓", "🕔", "🕕", "🕖", "🕗", "🕘", "🕙", "🕚"}, FPS: 6}
+	SpinnerBouncingBar = SpinnerFrames{Frames: []string{"[=   ]", "[==  ]", "[ == ]", "[  ==]", "[   =]", "[  ==]", "[ == ]", "[==  ]"}, FPS: 10}
+)
+
+// SpinnerTickMsg advances the Spinner that scheduled it. Pass every message
+// to Spinner.Update.
+type SpinnerTickMsg struct {
+	At      time.Time
+	id, seq int
+}
+
+// lastSpinnerID numbers the spinners, so each only follows its own ticks.
+var lastSpinnerID atomic.Int64
+
+// Spinner shows that something is going on. It runs its own tick loop at the
+// FPS of its frames, started by Init; ticks carry a sequence number, so
+// forwarding one twice, or calling Init again, never starts a second loop.
+//
+//	func (m model) Init() tea.Cmd {
+//		return m.spinner.Init()
+//	}
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//		var cmd tea.Cmd
+//		m.spinner, cmd = m.spinner.Update(msg)
+//		...
+type Spinner struct {
+	Frames SpinnerFrames
+	Label  string
+	Style  lipgloss.Style
+
+	id          int
+	seq         int       // Sequence of the tick the loop waits for.
+	start, last time.Time // First and last tick received.
+}
+
+// NewSpinner returns a spinner playing frames in the theme's CheckboxStyle.
+// A nil theme uses ui.DefaultTheme.
+func NewSpinner(theme *txui.Theme, frames SpinnerFrames) Spinner {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return Spinner{Frames: frames, Style: theme.CheckboxStyle, id: int(lastSpinnerID.Add(1))}
+}
+
+// ID identifies the spinner in the SpinnerTickMsg it schedules.
+func (m Spinner) ID() int {
+	return m.id
+}
+
+// Init starts the tick loop.
+func (m Spinner) Init() tea.Cmd {
+	return m.tick()
+}
+
+// tick schedules the next frame.
+func (m Spinner) tick() tea.Cmd {
+	id, seq := m.id, m.seq
+	return tea.Tick(time.Second/time.Duration(max(m.Frames.FPS, 1)), func(t time.Time) tea.Msg {
+		return SpinnerTickMsg{At: t, id: id, seq: seq}
+	})
+}
+
+// Update advances the spinner on its own ticks and schedules the next one.
+// Ticks of other spinners, and stale ones, are ignored.
+func (m Spinner) Update(msg tea.Msg) (Spinner, tea.Cmd) {
+	tick, ok := msg.(SpinnerTickMsg)
+	if !ok || tick.id != m.id || tick.seq != m.seq {
+		return m, nil
+	}
+	m.seq++
+	m.last = tick.At
+	if m.start.IsZero() {
+		m.start = m.last
+	}
+	return m, m.tick()
+}
+
+// View renders the current frame and the label.
+func (m Spinner) View() string {
+	if len(m.Frames.Frames) == 0 {
+		return m.Label
+	}
+	fps := max(m.Frames.FPS, 1)
+	n := int(m.last.Sub(m.start).Seconds() * float64(fps))
+	frame := m.Style.Render(m.Frames.Frames[n%len(m.Frames.Frames)])
+	if m.Label == "" {
+		return frame
+	}
+	return frame + " " + m.Label
+}
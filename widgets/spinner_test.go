@@ -0,0 +1,51 @@
+package widgets
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// liveLoops feeds the messages of cmds to sp for a few rounds and returns
+// how many loops are still scheduled afterwards.
+func liveLoops(t *testing.T, sp Spinner, cmds []tea.Cmd) int {
+	t.Helper()
+	for round := 0; round < 3; round++ {
+		var next []tea.Cmd
+		for _, cmd := range cmds {
+			var c tea.Cmd
+			sp, c = sp.Update(cmd())
+			if c != nil {
+				next = append(next, c)
+			}
+		}
+		cmds = next
+	}
+	return len(cmds)
+}
+
+func TestSpinnerSingleLoop(t *testing.T) {
+	sp := NewSpinner(nil, SpinnerFrames{Frames: []string{"a", "b"}, FPS: 200})
+	// Init called twice, as a parent restarting it would.
+	if n := liveLoops(t, sp, []tea.Cmd{sp.Init(), sp.Init()}); n != 1 {
+		t.Errorf("%d tick loops live, want 1", n)
+	}
+}
+
+func TestSpinnerForwardedTwice(t *testing.T) {
+	sp := NewSpinner(nil, SpinnerFrames{Frames: []string{"a", "b"}, FPS: 200})
+	msg := sp.Init()()
+	sp, first := sp.Update(msg)
+	sp, second := sp.Update(msg)
+	if first == nil || second != nil {
+		t.Errorf("same tick handled twice: first %v, second %v", first != nil, second != nil)
+	}
+}
+
+func TestSpinnerIgnoresOtherSpinners(t *testing.T) {
+	a := NewSpinner(nil, SpinnerDots)
+	b := NewSpinner(nil, SpinnerDots)
+	if _, cmd := a.Update(b.Init()()); cmd != nil {
+		t.Error("spinner followed the tick of another spinner")
+	}
+}
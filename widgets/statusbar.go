@@ -0,0 +1,250 @@
+package widgets
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	txui "txeo-tui-library/ui"
+)
+
+// StatusAlign is the side of a StatusBar a segment sits on.
+type StatusAlign int
+
+const (
+	StatusLeft StatusAlign = iota
+	StatusCenter
+	StatusRight
+)
+
+// StatusSegment is one piece of a StatusBar.
+type StatusSegment struct {
+	// ID names the segment for Set and Remove.
+	ID    string
+	Text  string
+	Align StatusAlign
+	// Style of the segment, on top of the theme's StatusBarStyle. The
+	// theme's StatusStyle, StatusStrong, StatusInfo and StatusPrimary are
+	// meant for segments.
+	Style lipgloss.Style
+	// Priority decides what goes when the bar is too narrow: segments with
+	// the lowest priority are truncated, then dropped, first.
+	Priority int
+	// MinWidth is the width the text may be truncated to before the segment
+	// is dropped. Zero never truncates it.
+	MinWidth int
+
+	clock   string // time.Format layout of clock segments.
+	spinner *Spinner
+}
+
+// ClockSegment returns a segment showing the time of the last ui.TickMsg in
+// layout.
+func ClockSegment(id, layout string) StatusSegment {
+	return StatusSegment{ID: id, Align: StatusRight, clock: layout}
+}
+
+// SpinnerSegment returns a segment showing sp, advanced by its own ticks.
+func SpinnerSegment(id string, sp Spinner) StatusSegment {
+	return StatusSegment{ID: id, spinner: &sp}
+}
+
+// text returns the content of the segment at now.
+func (s StatusSegment) text(now time.Time) string {
+	switch {
+	case s.clock != "":
+		return now.Format(s.clock)
+	case s.spinner != nil:
+		return s.spinner.View()
+	}
+	return s.Text
+}
+
+// StatusBar is a one-line bar of left, center and right segments. When the
+// terminal is too narrow, low-priority segments are truncated or dropped.
+//
+// Clock segments follow ui.TickMsg, which the bar does not schedule, so keep
+// ui.Tick running and forward its messages. Spinner segments run their own
+// loops, started by Init.
+//
+//	bar := widgets.NewStatusBar(theme,
+//		widgets.StatusSegment{Text: "TRELLO", Style: theme.StatusStyle, Priority: 3},
+//		widgets.StatusSegment{ID: "board", Text: board, Style: theme.StatusStrong, Priority: 2, MinWidth: 8},
+//		widgets.ClockSegment("clock", "15:04"),
+//	)
+type StatusBar struct {
+	// Width of the bar. Zero fills the width of the last tea.WindowSizeMsg.
+	Width int
+
+	theme          *txui.Theme
+	segments       []StatusSegment
+	containerWidth int
+	now            time.Time
+}
+
+// NewStatusBar returns a bar made of segments. A nil theme uses
+// ui.DefaultTheme.
+func NewStatusBar(theme *txui.Theme, segments ...StatusSegment) StatusBar {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return StatusBar{theme: theme, segments: segments, now: time.Now()}
+}
+
+// Init starts the spinner segments.
+func (m StatusBar) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, s := range m.segments {
+		if s.spinner != nil {
+			cmds = append(cmds, s.spinner.Init())
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// Segments returns a copy of the segments.
+func (m StatusBar) Segments() []StatusSegment {
+	return append([]StatusSegment(nil), m.segments...)
+}
+
+// Add appends a segment.
+func (m *StatusBar) Add(s StatusSegment) {
+	m.segments = append(m.segments, s)
+}
+
+// Remove removes the segment id.
+func (m *StatusBar) Remove(id string) {
+	segments := make([]StatusSegment, 0, len(m.segments))
+	for _, s := range m.segments {
+		if s.ID != id {
+			segments = append(segments, s)
+		}
+	}
+	m.segments = segments
+}
+
+// Set changes the text of the segment id.
+func (m *StatusBar) Set(id, text string) {
+	segments := m.Segments()
+	for i := range segments {
+		if segments[i].ID == id {
+			segments[i].Text = text
+		}
+	}
+	m.segments = segments
+}
+
+// Update follows the terminal width, the clock and the spinners.
+func (m StatusBar) Update(msg tea.Msg) (StatusBar, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.containerWidth = msg.Width
+	case txui.TickMsg:
+		m.now = time.Now()
+	case SpinnerTickMsg:
+		segments := m.Segments()
+		var cmds []tea.Cmd
+		for i, s := range segments {
+			if s.spinner != nil {
+				sp, cmd := s.spinner.Update(msg)
+				segments[i].spinner = &sp
+				cmds = append(cmds, cmd)
+			}
+		}
+		m.segments = segments
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+// width returns the width the bar takes, 0 when unknown.
+func (m StatusBar) width() int {
+	if m.Width > 0 {
+		return m.Width
+	}
+	return m.containerWidth
+}
+
+// statusCell is a segment laid out on the bar.
+type statusCell struct {
+	text     string
+	style    lipgloss.Style
+	overhead int // Width of padding, margins and borders.
+	dropped  bool
+}
+
+func (c statusCell) width() int {
+	return txui.StringWidth(c.text) + c.overhead
+}
+
+// layout truncates and drops the cells of the lowest priority segments
+// until they fit in width.
+func (m StatusBar) layout(width int) []statusCell {
+	cells := make([]statusCell, len(m.segments))
+	total := 0
+	for i, s := range m.segments {
+		text := s.text(m.now)
+		style := s.Style.Inherit(m.theme.StatusBarStyle)
+		c := statusCell{text: text, style: style}
+		c.overhead = lipgloss.Width(style.Render(text)) - txui.StringWidth(text)
+		cells[i] = c
+		total += c.width()
+	}
+	if width <= 0 || total <= width {
+		return cells
+	}
+	order := make([]int, len(cells))
+	for i := range order {
+		order[i] = i
+	}
+	// Lowest priority first; among equals, the rightmost goes first.
+	sort.SliceStable(order, func(a, b int) bool {
+		pa, pb := m.segments[order[a]].Priority, m.segments[order[b]].Priority
+		if pa != pb {
+			return pa < pb
+		}
+		return order[a] > order[b]
+	})
+	need := total - width
+	for _, i := range order {
+		if need <= 0 {
+			break
+		}
+		c := &cells[i]
+		w := txui.StringWidth(c.text)
+		if minW := m.segments[i].MinWidth; minW > 0 && w-need >= minW {
+			c.text = txui.Truncate(c.text, w-need, "…")
+			need = 0
+			break
+		}
+		c.dropped = true
+		need -= c.width()
+	}
+	return cells
+}
+
+// View renders the bar, filled with the theme's StatusBarStyle.
+func (m StatusBar) View() string {
+	width := m.width()
+	cells := m.layout(width)
+	var sides [3]string
+	for i, c := range cells {
+		if !c.dropped {
+			sides[m.segments[i].Align] += c.style.Render(c.text)
+		}
+	}
+	left, center, right := sides[StatusLeft], sides[StatusCenter], sides[StatusRight]
+	if width <= 0 {
+		return left + center + right
+	}
+	lw, cw, rw := lipgloss.Width(left), lipgloss.Width(center), lipgloss.Width(right)
+	// Center the middle segments on the bar, pushed aside by the others.
+	start := max((width-cw)/2, lw)
+	start = max(min(start, width-rw-cw), lw)
+	fill := m.theme.StatusBarStyle.Render
+	gap1 := max(start-lw, 0)
+	gap2 := max(width-lw-gap1-cw-rw, 0)
+	return left + fill(strings.Repeat(" ", gap1)) + center + fill(strings.Repeat(" ", gap2)) + right
+}
@@ -0,0 +1,67 @@
+package widgets
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	txui "txeo-tui-library/ui"
+)
+
+func TestStatusBarFit(t *testing.T) {
+	board := StatusSegment{ID: "board", Text: "board-name", Priority: 1, MinWidth: 5}
+	head := StatusSegment{ID: "head", Text: "HEAD", Priority: 2}
+	tests := []struct {
+		name     string
+		width    int
+		segments []StatusSegment
+		want     string
+	}{
+		{"fits", 20, []StatusSegment{board, head}, "board-nameHEAD      "},
+		{"truncated to fit", 11, []StatusSegment{board, head}, "board-…HEAD"},
+		{"dropped below its minimum", 8, []StatusSegment{board, head}, "HEAD    "},
+		{"no minimum drops", 6, []StatusSegment{
+			{Text: "aaaa", Priority: 1},
+			{Text: "bbbb", Priority: 2, MinWidth: 1},
+		}, "bbbb  "},
+		{"rightmost of equals goes first", 8, []StatusSegment{
+			{Text: "aaaa", Priority: 1},
+			{Text: "cccc", Priority: 2},
+			{Text: "bbbb", Priority: 1, Align: StatusRight},
+		}, "aaaacccc"},
+		{"drops until it fits", 4, []StatusSegment{
+			{Text: "aa", Priority: 1},
+			{Text: "bb", Priority: 2},
+			{Text: "cccc", Priority: 3, Align: StatusRight},
+		}, "cccc"},
+		{"sides", 12, []StatusSegment{
+			{Text: "L"},
+			{Text: "C", Align: StatusCenter},
+			{Text: "R", Align: StatusRight},
+		}, "L    C     R"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewStatusBar(nil, tt.segments...)
+			m.Width = tt.width
+			got := m.View()
+			if plain := txui.StripANSI(got); plain != tt.want {
+				t.Errorf("View() = %q, want %q", plain, tt.want)
+			}
+			if w := lipgloss.Width(got); w != tt.width {
+				t.Errorf("View() is %d cells wide, want %d", w, tt.width)
+			}
+		})
+	}
+}
+
+func TestStatusBarSetCopies(t *testing.T) {
+	m := NewStatusBar(nil, StatusSegment{ID: "board", Text: "old"})
+	old := m
+	m.Set("board", "new")
+	if got := old.Segments()[0].Text; got != "old" {
+		t.Errorf("Set changed the copy of the bar: %q", got)
+	}
+	if got := m.Segments()[0].Text; got != "new" {
+		t.Errorf("Set did not change the bar: %q", got)
+	}
+}
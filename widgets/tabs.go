@@ -0,0 +1,400 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	txui "txeo-tui-library/ui"
+)
+
+// Tab is one tab of a Tabs bar.
+type Tab struct {
+	Title string
+	// Badge is a count shown next to the title, hidden when zero.
+	Badge int
+	// Closable adds a close button to the tab.
+	Closable bool
+}
+
+// TabSelectedMsg is sent when the user switches to another tab.
+type TabSelectedMsg struct {
+	Index int
+	Tab   Tab
+}
+
+// TabClosedMsg is sent when the user closes a tab.
+type TabClosedMsg struct {
+	Index int
+	Tab   Tab
+}
+
+// TabsKeyMap holds the key bindings of a Tabs bar.
+type TabsKeyMap struct {
+	Prev, Next key.Binding
+	// Close closes the active tab if it is closable.
+	Close key.Binding
+}
+
+// DefaultTabsKeyMap returns the default bindings.
+func DefaultTabsKeyMap() TabsKeyMap {
+	return TabsKeyMap{
+		Prev:  key.NewBinding(key.WithKeys("shift+tab", "ctrl+pgup"), key.WithHelp("shift+tab", "previous tab")),
+		Next:  key.NewBinding(key.WithKeys("tab", "ctrl+pgdown"), key.WithHelp("tab", "next tab")),
+		Close: key.NewBinding(key.WithKeys("ctrl+w"), key.WithHelp("ctrl+w", "close tab")),
+	}
+}
+
+const (
+	tabCloseGlyph = "×"
+	tabPrevArrow  = "‹"
+	tabNextArrow  = "›"
+)
+
+// Tabs is a tab bar drawn with the theme's ActiveTab, RegularTab and TabGap
+// styles, the gap line filling the rest of the width. When the tabs do not
+// fit, the bar scrolls and shows arrows on the side of the hidden ones.
+//
+// Tabs can be switched with the keyboard and the mouse; set X and Y to where
+// the bar is drawn for clicks to land on the right tab.
+type Tabs struct {
+	KeyMap TabsKeyMap
+	// Width of the bar. Zero fills the width of the last tea.WindowSizeMsg.
+	Width int
+	// X and Y are the screen cell of the top-left corner of the bar.
+	X, Y int
+
+	theme          *txui.Theme
+	tabs           []Tab
+	active         int
+	offset         int // First visible tab.
+	containerWidth int
+	focused        bool
+}
+
+// NewTabs returns a focused bar with a tab per title, the first one active.
+// A nil theme uses ui.DefaultTheme.
+func NewTabs(theme *txui.Theme, titles ...string) Tabs {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	m := Tabs{KeyMap: DefaultTabsKeyMap(), theme: theme, focused: true}
+	for _, title := range titles {
+		m.tabs = append(m.tabs, Tab{Title: title})
+	}
+	return m
+}
+
+// Init implements tea.Model.
+func (m Tabs) Init() tea.Cmd {
+	return nil
+}
+
+// Focus makes the bar react to keys.
+func (m *Tabs) Focus() {
+	m.focused = true
+}
+
+// Blur makes the bar ignore keys. Mouse clicks still work.
+func (m *Tabs) Blur() {
+	m.focused = false
+}
+
+// Focused reports whether the bar reacts to keys.
+func (m Tabs) Focused() bool {
+	return m.focused
+}
+
+// Len returns the number of tabs.
+func (m Tabs) Len() int {
+	return len(m.tabs)
+}
+
+// Tabs returns a copy of the tabs.
+func (m Tabs) Tabs() []Tab {
+	return append([]Tab(nil), m.tabs...)
+}
+
+// Active returns the index of the active tab, -1 when there are none.
+func (m Tabs) Active() int {
+	if len(m.tabs) == 0 {
+		return -1
+	}
+	return m.active
+}
+
+// SetActive makes tab i the active one and scrolls it into view.
+func (m *Tabs) SetActive(i int) {
+	if i < 0 || i >= len(m.tabs) {
+		return
+	}
+	m.active = i
+	m.scrollTo(i)
+}
+
+// AddTab appends tab and returns its index.
+func (m *Tabs) AddTab(tab Tab) int {
+	m.tabs = append(m.tabs, tab)
+	return len(m.tabs) - 1
+}
+
+// RemoveTab removes tab i. The active tab stays active; when it is the one
+// removed, the tab after it becomes active, or the one before at the end.
+func (m *Tabs) RemoveTab(i int) {
+	if i < 0 || i >= len(m.tabs) {
+		return
+	}
+	m.tabs = append(m.tabs[:i:i], m.tabs[i+1:]...)
+	if m.active > i || m.active == len(m.tabs) {
+		m.active = max(m.active-1, 0)
+	}
+	// Scroll back as far as the active tab allows, so no gap is left at
+	// the end while tabs hide on the left.
+	m.offset = 0
+	m.scrollTo(m.active)
+}
+
+// Rename changes the title of tab i.
+func (m *Tabs) Rename(i int, title string) {
+	if i >= 0 && i < len(m.tabs) {
+		m.tabs[i].Title = title
+	}
+}
+
+// SetBadge sets the count shown on tab i; zero hides it.
+func (m *Tabs) SetBadge(i, n int) {
+	if i >= 0 && i < len(m.tabs) {
+		m.tabs[i].Badge = n
+	}
+}
+
+// width returns the width the bar takes.
+func (m Tabs) width() int {
+	if m.Width > 0 {
+		return m.Width
+	}
+	return m.containerWidth
+}
+
+// suffix returns the badge and close button shown after the title of tab i.
+func (m Tabs) suffix(i int) string {
+	tab := m.tabs[i]
+	var suffix string
+	if tab.Badge != 0 {
+		suffix += " " + m.theme.KeywordStyle.Render(fmt.Sprint(tab.Badge))
+	}
+	if tab.Closable {
+		suffix += " " + tabCloseGlyph
+	}
+	return suffix
+}
+
+// fullWidth returns the width of tab i with its whole title, border and
+// padding included.
+func (m Tabs) fullWidth(i int) int {
+	return txui.StringWidth(m.tabs[i].Title) + lipgloss.Width(m.suffix(i)) + 4
+}
+
+// label returns the text inside tab i. When the bar scrolls, a title too
+// long to fit between the arrows on its own is cut short.
+func (m Tabs) label(i int) string {
+	title, suffix := m.tabs[i].Title, m.suffix(i)
+	if avail := m.width() - 2*arrowWidth; m.fullWidth(i) > avail && m.overflows() {
+		title = txui.Truncate(title, max(avail-4-lipgloss.Width(suffix), 0), "…")
+	}
+	return title + suffix
+}
+
+// tabWidth returns the width of tab i, border and padding included.
+func (m Tabs) tabWidth(i int) int {
+	return lipgloss.Width(m.label(i)) + 4
+}
+
+// overflows reports whether the tabs need more than the width.
+func (m Tabs) overflows() bool {
+	w := m.width()
+	if w <= 0 {
+		return false
+	}
+	total := 0
+	for i := range m.tabs {
+		total += m.fullWidth(i)
+	}
+	return total > w
+}
+
+// arrowWidth is the width of a scroll arrow, padding included.
+const arrowWidth = 3
+
+// visible returns the range of tabs shown from the scroll offset.
+func (m Tabs) visible() (from, to int) {
+	if !m.overflows() {
+		return 0, len(m.tabs)
+	}
+	avail := m.width() - 2*arrowWidth
+	from, to = m.offset, m.offset
+	for used := 0; to < len(m.tabs) && used+m.tabWidth(to) <= avail; to++ {
+		used += m.tabWidth(to)
+	}
+	// Always show at least one tab; labels are cut short to fit.
+	return from, max(to, min(from+1, len(m.tabs)))
+}
+
+// scrollTo moves the scroll offset the least needed to show tab i.
+func (m *Tabs) scrollTo(i int) {
+	if !m.overflows() {
+		m.offset = 0
+		return
+	}
+	if i < m.offset {
+		m.offset = i
+		return
+	}
+	for {
+		if _, to := m.visible(); i < to || m.offset >= i {
+			return
+		}
+		m.offset++
+	}
+}
+
+// selectTab activates tab i and reports it.
+func (m *Tabs) selectTab(i int) tea.Cmd {
+	if i < 0 || i >= len(m.tabs) || i == m.active {
+		return nil
+	}
+	m.SetActive(i)
+	msg := TabSelectedMsg{Index: i, Tab: m.tabs[i]}
+	return func() tea.Msg { return msg }
+}
+
+// closeTab closes tab i if it is closable and reports it.
+func (m *Tabs) closeTab(i int) tea.Cmd {
+	if i < 0 || i >= len(m.tabs) || !m.tabs[i].Closable {
+		return nil
+	}
+	msg := TabClosedMsg{Index: i, Tab: m.tabs[i]}
+	m.RemoveTab(i)
+	return func() tea.Msg { return msg }
+}
+
+// Update switches and closes tabs with the keys and the mouse.
+func (m Tabs) Update(msg tea.Msg) (Tabs, tea.Cmd) {
+	if len(m.tabs) == 0 {
+		if size, ok := msg.(tea.WindowSizeMsg); ok {
+			m.containerWidth = size.Width
+		}
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.containerWidth = msg.Width
+		m.scrollTo(m.active)
+	case tea.KeyMsg:
+		if !m.focused {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.KeyMap.Prev):
+			return m, m.selectTab((m.active - 1 + len(m.tabs)) % len(m.tabs))
+		case key.Matches(msg, m.KeyMap.Next):
+			return m, m.selectTab((m.active + 1) % len(m.tabs))
+		case key.Matches(msg, m.KeyMap.Close):
+			return m, m.closeTab(m.active)
+		}
+	case tea.MouseMsg:
+		return m.mouse(msg)
+	}
+	return m, nil
+}
+
+// mouse handles clicks on the tabs, their close buttons and the arrows, and
+// the wheel.
+func (m Tabs) mouse(msg tea.MouseMsg) (Tabs, tea.Cmd) {
+	x, y := msg.X-m.X, msg.Y-m.Y
+	if y < 0 || y > 2 || x < 0 {
+		return m, nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelLeft:
+		return m, m.selectTab(m.active - 1)
+	case tea.MouseButtonWheelDown, tea.MouseButtonWheelRight:
+		return m, m.selectTab(m.active + 1)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+	default:
+		return m, nil
+	}
+	from, to := m.visible()
+	left := 0
+	if m.overflows() {
+		if x < arrowWidth {
+			m.offset = max(m.offset-1, 0)
+			return m, nil
+		}
+		if x >= m.width()-arrowWidth {
+			if to < len(m.tabs) {
+				m.offset++
+			}
+			return m, nil
+		}
+		left = arrowWidth
+	}
+	for i := from; i < to; i++ {
+		w := m.tabWidth(i)
+		if x < left+w {
+			// The close button is the last character before the padding.
+			if m.tabs[i].Closable && x == left+w-3 {
+				return m, m.closeTab(i)
+			}
+			return m, m.selectTab(i)
+		}
+		left += w
+	}
+	return m, nil
+}
+
+// View renders the bar, three lines high.
+func (m Tabs) View() string {
+	t := m.theme
+	from, to := m.visible()
+	overflow := m.overflows()
+	var row []string
+	if overflow {
+		row = append(row, m.arrow(tabPrevArrow, from > 0))
+	}
+	for i := from; i < to; i++ {
+		style := t.RegularTab
+		if i == m.active {
+			style = t.ActiveTab
+		}
+		row = append(row, style.Render(m.label(i)))
+	}
+	bar := lipgloss.JoinHorizontal(lipgloss.Bottom, row...)
+	if overflow {
+		gap := m.width() - lipgloss.Width(bar) - arrowWidth
+		return lipgloss.JoinHorizontal(lipgloss.Bottom, bar, m.gap(gap), m.arrow(tabNextArrow, to < len(m.tabs)))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, bar, m.gap(m.width()-lipgloss.Width(bar)))
+}
+
+// gap renders w cells of the gap line.
+func (m Tabs) gap(w int) string {
+	if w <= 0 {
+		return ""
+	}
+	return m.theme.TabGap.Padding(0).Render(strings.Repeat(" ", w))
+}
+
+// arrow renders a scroll arrow on the gap line, dimmed when there is nothing
+// to scroll to.
+func (m Tabs) arrow(glyph string, more bool) string {
+	if !more {
+		glyph = m.theme.SuggestionStyle.Render(glyph)
+	}
+	return m.theme.TabGap.Render(glyph)
+}
@@ -0,0 +1,120 @@
+package widgets
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func click(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+}
+
+func TestTabsKeys(t *testing.T) {
+	m := NewTabs(nil, "One", "Two", "Three")
+	m, cmd := m.Update(keyTab)
+	if got, ok := cmd().(TabSelectedMsg); !ok || got.Index != 1 || got.Tab.Title != "Two" {
+		t.Errorf("tab sent %#v, want TabSelectedMsg for Two", cmd())
+	}
+	m, _ = m.Update(keyShiftTab)
+	m, cmd = m.Update(keyShiftTab)
+	if m.Active() != 2 || cmd == nil {
+		t.Errorf("shift+tab from the first tab: active = %d, want 2", m.Active())
+	}
+
+	m.Blur()
+	if m, _ = m.Update(keyTab); m.Active() != 2 {
+		t.Errorf("blurred bar switched to tab %d", m.Active())
+	}
+	m.Focus()
+
+	ctrlW := tea.KeyMsg{Type: tea.KeyCtrlW}
+	if _, cmd = m.Update(ctrlW); cmd != nil {
+		t.Errorf("ctrl+w closed a tab that is not closable: %#v", cmd())
+	}
+	m.tabs[2].Closable = true
+	m, cmd = m.Update(ctrlW)
+	if got, ok := cmd().(TabClosedMsg); !ok || got.Index != 2 || got.Tab.Title != "Three" {
+		t.Errorf("ctrl+w sent %#v, want TabClosedMsg for Three", cmd())
+	}
+	if m.Len() != 2 || m.Active() != 1 {
+		t.Errorf("after closing the last tab: len = %d, active = %d, want 2 and 1", m.Len(), m.Active())
+	}
+}
+
+func TestTabsMouse(t *testing.T) {
+	m := NewTabs(nil, "One", "Two", "Three")
+	m.X, m.Y = 10, 5
+	m.tabs[1].Closable = true
+	// "One" takes cells 10-16 and "Two ×" 17-25, its × at 23.
+	if _, cmd := m.Update(click(12, 6)); cmd != nil {
+		t.Errorf("click on the active tab sent %#v", cmd())
+	}
+	if _, cmd := m.Update(click(12, 8)); cmd != nil {
+		t.Errorf("click below the bar sent %#v", cmd())
+	}
+	got, cmd := m.Update(click(18, 6))
+	if got.Active() != 1 || cmd == nil {
+		t.Errorf("click on Two: active = %d, want 1", got.Active())
+	}
+	got, cmd = m.Update(click(23, 6))
+	if msg, ok := cmd().(TabClosedMsg); !ok || msg.Index != 1 {
+		t.Errorf("click on the close button sent %#v, want TabClosedMsg for tab 1", cmd())
+	}
+	if got.Len() != 2 || got.Tabs()[1].Title != "Three" {
+		t.Errorf("tabs after closing Two = %+v", got.Tabs())
+	}
+	if got, _ = m.Update(click(26, 6)); got.Active() != 2 {
+		t.Errorf("click on Three: active = %d, want 2", got.Active())
+	}
+	wheel := tea.MouseMsg{X: 12, Y: 6, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress}
+	if got, _ = m.Update(wheel); got.Active() != 1 {
+		t.Errorf("wheel down: active = %d, want 1", got.Active())
+	}
+}
+
+func TestTabsOverflow(t *testing.T) {
+	m := NewTabs(nil, "Alpha", "Bravo", "Charlie", "Delta")
+	m.Width = 20
+	view := func() string {
+		t.Helper()
+		v := m.View()
+		for _, line := range strings.Split(v, "\n") {
+			if w := lipgloss.Width(line); w != m.Width {
+				t.Errorf("line %q is %d cells wide, want %d", line, w, m.Width)
+			}
+		}
+		return v
+	}
+	if v := view(); !strings.Contains(v, "Alpha") || strings.Contains(v, "Bravo") {
+		t.Errorf("first view shows the wrong tabs:\n%s", v)
+	}
+
+	m, _ = m.Update(keyTab)
+	if v := view(); !strings.Contains(v, "Bravo") || strings.Contains(v, "Alpha") {
+		t.Errorf("the active tab was not scrolled into view:\n%s", v)
+	}
+
+	// The arrows scroll without switching tabs.
+	m, _ = m.Update(click(19, 1))
+	if v := view(); !strings.Contains(v, "Charlie") || m.Active() != 1 {
+		t.Errorf("the right arrow did not scroll (active %d):\n%s", m.Active(), v)
+	}
+	m, _ = m.Update(click(0, 1))
+	m, _ = m.Update(click(0, 1))
+	if v := view(); !strings.Contains(v, "Alpha") {
+		t.Errorf("the left arrow did not scroll back:\n%s", v)
+	}
+	m, _ = m.Update(click(5, 1))
+	if m.Active() != 0 {
+		t.Errorf("click on the scrolled-back tab: active = %d, want 0", m.Active())
+	}
+
+	m.SetActive(3)
+	m.RemoveTab(3)
+	if v := view(); m.Active() != 2 || !strings.Contains(v, "Charlie") {
+		t.Errorf("after removing the last tab: active = %d\n%s", m.Active(), v)
+	}
+}
@@ -0,0 +1,325 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	txui "txeo-tui-library/ui"
+)
+
+// ToastLevel is the kind of a notification, which sets its icon and color.
+type ToastLevel int
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarning
+	ToastError
+)
+
+// Icon returns the glyph shown before notifications of the level.
+func (l ToastLevel) Icon() string {
+	switch l {
+	case ToastSuccess:
+		return "✓"
+	case ToastWarning:
+		return "⚠"
+	case ToastError:
+		return "✗"
+	}
+	return "ℹ"
+}
+
+// style returns the style of the level in t.
+func (l ToastLevel) style(t *txui.Theme) lipgloss.Style {
+	switch l {
+	case ToastSuccess:
+		return t.SuccessStyle
+	case ToastWarning:
+		return t.WarningStyle
+	case ToastError:
+		return t.ErrorStyle
+	}
+	return t.NoticeStyle
+}
+
+// ToastCorner is the corner of the screen toasts stack in.
+type ToastCorner int
+
+const (
+	ToastTopRight ToastCorner = iota
+	ToastTopLeft
+	ToastBottomRight
+	ToastBottomLeft
+)
+
+// Toast is a notification.
+type Toast struct {
+	ID    int
+	Level ToastLevel
+	Text  string
+	// Count is how many times the same notification was pushed while shown.
+	Count int
+	// At is when it was last pushed.
+	At time.Time
+
+	gen int // Bumped by repeats, so timers of earlier pushes are ignored.
+}
+
+// toastExpiredMsg dismisses toast id unless it was pushed again since.
+type toastExpiredMsg struct {
+	id, gen int
+}
+
+// Toasts shows notifications stacked in a corner of the screen, each for
+// TTL. Pushing a notification already on screen bumps its counter and
+// restarts its timer instead of stacking a copy. Dismissed notifications are
+// kept in a history, shown by HistoryView.
+//
+//	cmd := m.toasts.Push(widgets.ToastSuccess, "Board saved")
+//
+//	func (m model) View() string {
+//		return m.toasts.View(m.content())
+//	}
+type Toasts struct {
+	// TTL is how long a toast stays; zero or less keeps it until dismissed.
+	TTL time.Duration
+	// Max is how many toasts are shown at once; the rest wait below a "+n
+	// more" line.
+	Max int
+	// Width of a toast, border included.
+	Width  int
+	Corner ToastCorner
+	// HistorySize is how many notifications the history keeps.
+	HistorySize int
+
+	theme         *txui.Theme
+	active        []Toast
+	history       []Toast // Newest last.
+	nextID        int
+	width, height int
+}
+
+// NewToasts returns an empty manager showing up to 4 toasts for 4 seconds
+// in the top right corner. A nil theme uses ui.DefaultTheme.
+func NewToasts(theme *txui.Theme) Toasts {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return Toasts{
+		TTL:         4 * time.Second,
+		Max:         4,
+		Width:       36,
+		HistorySize: 100,
+		theme:       theme,
+	}
+}
+
+// Init implements tea.Model.
+func (m Toasts) Init() tea.Cmd {
+	return nil
+}
+
+// Push shows a notification for TTL.
+func (m *Toasts) Push(level ToastLevel, text string) tea.Cmd {
+	return m.PushTTL(level, text, m.TTL)
+}
+
+// Info, Success, Warning and Error push a notification of their level.
+func (m *Toasts) Info(text string) tea.Cmd    { return m.Push(ToastInfo, text) }
+func (m *Toasts) Success(text string) tea.Cmd { return m.Push(ToastSuccess, text) }
+func (m *Toasts) Warning(text string) tea.Cmd { return m.Push(ToastWarning, text) }
+func (m *Toasts) Error(text string) tea.Cmd   { return m.Push(ToastError, text) }
+
+// PushTTL shows a notification for ttl; zero or less keeps it until
+// dismissed.
+func (m *Toasts) PushTTL(level ToastLevel, text string, ttl time.Duration) tea.Cmd {
+	now := time.Now()
+	active := append([]Toast(nil), m.active...)
+	i := -1
+	for j, t := range active {
+		if t.Level == level && t.Text == text {
+			i = j
+		}
+	}
+	var toast Toast
+	if i >= 0 {
+		// Repeats move up to the newest place.
+		toast = active[i]
+		toast.Count++
+		toast.gen++
+		active = append(active[:i], active[i+1:]...)
+	} else {
+		m.nextID++
+		toast = Toast{ID: m.nextID, Level: level, Text: text, Count: 1}
+	}
+	toast.At = now
+	m.active = append(active, toast)
+	m.record(toast)
+	if ttl <= 0 {
+		return nil
+	}
+	msg := toastExpiredMsg{id: toast.ID, gen: toast.gen}
+	return tea.Tick(ttl, func(time.Time) tea.Msg { return msg })
+}
+
+// record adds or refreshes t in the history.
+func (m *Toasts) record(t Toast) {
+	history := append([]Toast(nil), m.history...)
+	for i := range history {
+		if history[i].ID == t.ID {
+			history = append(history[:i], history[i+1:]...)
+			break
+		}
+	}
+	history = append(history, t)
+	if m.HistorySize > 0 && len(history) > m.HistorySize {
+		history = history[len(history)-m.HistorySize:]
+	}
+	m.history = history
+}
+
+// Active returns the toasts on screen, oldest first.
+func (m Toasts) Active() []Toast {
+	return append([]Toast(nil), m.active...)
+}
+
+// History returns the notifications pushed, newest first.
+func (m Toasts) History() []Toast {
+	history := make([]Toast, len(m.history))
+	for i, t := range m.history {
+		history[len(m.history)-1-i] = t
+	}
+	return history
+}
+
+// Dismiss removes toast id from the screen.
+func (m *Toasts) Dismiss(id int) {
+	active := make([]Toast, 0, len(m.active))
+	for _, t := range m.active {
+		if t.ID != id {
+			active = append(active, t)
+		}
+	}
+	m.active = active
+}
+
+// DismissAll clears the screen of toasts.
+func (m *Toasts) DismissAll() {
+	m.active = nil
+}
+
+// ClearHistory forgets the notifications pushed so far.
+func (m *Toasts) ClearHistory() {
+	m.history = nil
+}
+
+// Update follows the terminal size and dismisses expired toasts.
+func (m Toasts) Update(msg tea.Msg) (Toasts, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case toastExpiredMsg:
+		for _, t := range m.active {
+			if t.ID == msg.id && t.gen == msg.gen {
+				m.Dismiss(t.ID)
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+// render draws one toast.
+func (m Toasts) render(t Toast) string {
+	th := m.theme
+	level := t.Level.style(th)
+	text := level.Bold(true).Render(t.Level.Icon()) + " " + t.Text
+	if t.Count > 1 {
+		text += th.HelpStyle.Render(fmt.Sprintf(" ×%d", t.Count))
+	}
+	return th.ToastStyle.
+		BorderForeground(level.GetForeground()).
+		Width(max(m.Width-2, 1)).
+		Render(text)
+}
+
+// Stack renders the toasts on screen, newest nearest to the corner.
+func (m Toasts) Stack() string {
+	if len(m.active) == 0 {
+		return ""
+	}
+	shown := m.active
+	if m.Max > 0 && len(shown) > m.Max {
+		shown = shown[len(shown)-m.Max:]
+	}
+	top := m.Corner == ToastTopRight || m.Corner == ToastTopLeft
+	align := lipgloss.Right
+	if m.Corner == ToastTopLeft || m.Corner == ToastBottomLeft {
+		align = lipgloss.Left
+	}
+	blocks := make([]string, 0, len(shown)+1)
+	for i := range shown {
+		if top {
+			blocks = append(blocks, m.render(shown[len(shown)-1-i]))
+		} else {
+			blocks = append(blocks, m.render(shown[i]))
+		}
+	}
+	if hidden := len(m.active) - len(shown); hidden > 0 {
+		more := m.theme.HelpStyle.Render(fmt.Sprintf("+%d more", hidden))
+		if top {
+			blocks = append(blocks, more)
+		} else {
+			blocks = append([]string{more}, blocks...)
+		}
+	}
+	return lipgloss.JoinVertical(align, blocks...)
+}
+
+// View draws the stack over background in the corner, with a ui.Compositor.
+func (m Toasts) View(background string) string {
+	stack := m.Stack()
+	if stack == "" {
+		return background
+	}
+	width := max(m.width, lipgloss.Width(background))
+	height := max(m.height, lipgloss.Height(background))
+	sw, sh := lipgloss.Width(stack), lipgloss.Height(stack)
+	x, y := width-sw-1, 1
+	if m.Corner == ToastTopLeft || m.Corner == ToastBottomLeft {
+		x = 1
+	}
+	if m.Corner == ToastBottomRight || m.Corner == ToastBottomLeft {
+		y = height - sh - 1
+	}
+	return txui.NewCompositor(width, height).Profile(m.theme.ColorProfile()).Add(txui.NewLayer(stack).At(x, y)).Render(background)
+}
+
+// HistoryView renders up to rows of the history, newest first, in the
+// theme's PanelStyle, width cells wide.
+func (m Toasts) HistoryView(width, rows int) string {
+	t := m.theme
+	var sb strings.Builder
+	sb.WriteString(t.KeywordStyle.Render("Notifications"))
+	inner := max(width-8, 1) // PanelStyle border, padding and margin.
+	history := m.History()
+	if len(history) == 0 {
+		sb.WriteString("\n" + t.HelpStyle.Render("Nothing yet"))
+	}
+	for i, n := range history {
+		if i == rows {
+			break
+		}
+		icon := n.Level.style(t).Render(n.Level.Icon())
+		line := t.HelpStyle.Render(n.At.Format("15:04:05")) + " " + icon + " " + n.Text
+		if n.Count > 1 {
+			line += t.HelpStyle.Render(fmt.Sprintf(" ×%d", n.Count))
+		}
+		sb.WriteString("\n" + txui.Truncate(line, inner, "…"))
+	}
+	return t.PanelStyle.Align(lipgloss.Left).Width(width - 4).Render(sb.String())
+}
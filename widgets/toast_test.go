@@ -0,0 +1,76 @@
+package widgets
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	txui "txeo-tui-library/ui"
+)
+
+func TestToastsDedupe(t *testing.T) {
+	m := NewToasts(nil)
+	m.TTL = 0
+	m.Info("saved")
+	m.Error("failed")
+	old := m
+	m.Info("saved")
+	m.Error("saved") // Another level is another toast.
+
+	active := m.Active()
+	if len(active) != 3 {
+		t.Fatalf("len(Active()) = %d, want 3: %+v", len(active), active)
+	}
+	if got := active[1]; got.Text != "saved" || got.Level != ToastInfo || got.Count != 2 {
+		t.Errorf("the repeat did not move up with a count of 2: %+v", active)
+	}
+	if got := old.Active()[0]; got.Count != 1 {
+		t.Errorf("pushing changed the copy of the manager: %+v", got)
+	}
+	if h := m.History(); len(h) != 3 || h[1].Text != "saved" || h[1].Count != 2 {
+		t.Errorf("History() = %+v, want the repeat once, with a count of 2", h)
+	}
+}
+
+func TestToastsExpiry(t *testing.T) {
+	m := NewToasts(nil)
+	m.TTL = time.Millisecond
+	first := m.Info("saved")
+	second := m.Info("saved")
+
+	// The timer of the first push was overtaken by the second one.
+	m, _ = m.Update(first())
+	if len(m.Active()) != 1 {
+		t.Fatal("a stale timer dismissed the repeated toast")
+	}
+	m, _ = m.Update(second())
+	if len(m.Active()) != 0 {
+		t.Errorf("the current timer did not dismiss the toast: %+v", m.Active())
+	}
+	if h := m.History(); len(h) != 1 || h[0].Count != 2 {
+		t.Errorf("History() = %+v, want the dismissed toast", h)
+	}
+
+	if cmd := m.PushTTL(ToastWarning, "sticky", 0); cmd != nil {
+		t.Error("a toast without TTL scheduled a timer")
+	}
+}
+
+func TestToastsStack(t *testing.T) {
+	m := NewToasts(nil)
+	m.TTL, m.Max = 0, 2
+	for _, text := range []string{"one", "two", "three"} {
+		m.Info(text)
+	}
+	stack := txui.StripANSI(m.Stack())
+	if strings.Contains(stack, "one") || !strings.Contains(stack, "+1 more") {
+		t.Errorf("Stack() does not hide the oldest toast:\n%s", stack)
+	}
+	if strings.Index(stack, "three") > strings.Index(stack, "two") {
+		t.Errorf("Stack() does not put the newest toast in the top corner:\n%s", stack)
+	}
+	m.DismissAll()
+	if got := m.View("background"); got != "background" {
+		t.Errorf("View() without toasts = %q, want the background", got)
+	}
+}
@@ -0,0 +1,114 @@
+package widgets
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	txui "txeo-tui-library/ui"
+)
+
+// ToggleSwitchKeyMap holds the key bindings of a ToggleSwitch.
+type ToggleSwitchKeyMap struct {
+	Toggle  key.Binding
+	On, Off key.Binding
+}
+
+// DefaultToggleSwitchKeyMap returns the default bindings.
+func DefaultToggleSwitchKeyMap() ToggleSwitchKeyMap {
+	return ToggleSwitchKeyMap{
+		Toggle: key.NewBinding(key.WithKeys(" ", "enter"), key.WithHelp("space", "toggle")),
+		On:     key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→", "on")),
+		Off:    key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←", "off")),
+	}
+}
+
+// ToggleSwitch is an on/off input.
+type ToggleSwitch struct {
+	KeyMap ToggleSwitchKeyMap
+	Label  string
+	// On and Off are the switch as drawn in each state.
+	On, Off string
+
+	theme   *txui.Theme
+	on      bool
+	focused bool
+}
+
+// NewToggleSwitch returns a focused switch, off. A nil theme uses
+// ui.DefaultTheme.
+func NewToggleSwitch(theme *txui.Theme, label string) ToggleSwitch {
+	if theme == nil {
+		theme = txui.DefaultTheme()
+	}
+	return ToggleSwitch{
+		KeyMap:  DefaultToggleSwitchKeyMap(),
+		Label:   label,
+		On:      "━━● on",
+		Off:     "●━━ off",
+		theme:   theme,
+		focused: true,
+	}
+}
+
+// Init implements tea.Model.
+func (m ToggleSwitch) Init() tea.Cmd {
+	return nil
+}
+
+// Value reports whether the switch is on.
+func (m ToggleSwitch) Value() bool {
+	return m.on
+}
+
+// SetValue turns the switch on or off.
+func (m *ToggleSwitch) SetValue(on bool) {
+	m.on = on
+}
+
+// Focus makes the switch react to keys.
+func (m *ToggleSwitch) Focus() {
+	m.focused = true
+}
+
+// Blur makes the switch ignore keys.
+func (m *ToggleSwitch) Blur() {
+	m.focused = false
+}
+
+// Focused reports whether the switch reacts to keys.
+func (m ToggleSwitch) Focused() bool {
+	return m.focused
+}
+
+// Update handles the keys while the switch is focused.
+func (m ToggleSwitch) Update(msg tea.Msg) (ToggleSwitch, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focused {
+		return m, nil
+	}
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.Toggle):
+		m.on = !m.on
+	case key.Matches(keyMsg, m.KeyMap.On):
+		m.on = true
+	case key.Matches(keyMsg, m.KeyMap.Off):
+		m.on = false
+	}
+	return m, nil
+}
+
+// View renders the label and the switch, on in the theme's CheckboxStyle
+// and off in its SubtleStyle.
+func (m ToggleSwitch) View() string {
+	cursor := "  "
+	if m.focused {
+		cursor = m.theme.CheckboxStyle.Render("> ")
+	}
+	label := ""
+	if m.Label != "" {
+		label = m.Label + " "
+	}
+	if m.on {
+		return cursor + label + m.theme.CheckboxStyle.Render(m.On)
+	}
+	return cursor + label + m.theme.SubtleStyle.Render(m.Off)
+}